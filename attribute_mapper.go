@@ -0,0 +1,354 @@
+package goshopee
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrUnknownAttribute is the reason of an UnmappedValue whose attribute
+	// is not an attribute of the category
+	ErrUnknownAttribute = errors.New("unknown attribute")
+	// ErrNoAttributeValue is returned for a text matching no value of an
+	// attribute not taking custom values, or not a valid custom value
+	ErrNoAttributeValue = errors.New("no matching attribute value")
+	// ErrAttributeUnit is returned for a quantity whose unit is missing or
+	// can't be converted to a unit of the attribute
+	ErrAttributeUnit = errors.New("invalid attribute unit")
+	// ErrSingleValue is the reason of the values after the first one given to
+	// an attribute taking a single value
+	ErrSingleValue = errors.New("attribute takes a single value")
+)
+
+// UnmappedValue is a value AttributeMapper could not turn into an
+// ItemAttributeValue
+type UnmappedValue struct {
+	Attribute   string // name of the attribute in the source
+	AttributeID uint64 // 0 for an unknown attribute
+	Value       string
+	Err         error
+}
+
+// AttributeMapping is the result of AttributeMapper.Map
+type AttributeMapping struct {
+	Attributes []ItemAttribute
+	Unmapped   []UnmappedValue
+
+	// Missing are the mandatory attributes no value was mapped to
+	Missing []Attribute
+}
+
+// AttributeMapper maps free form attributes, like the ones of a PIM, to the
+// attributes of a category:
+//
+//	res, _ := client.Product.GetAttributes(sid, cid, "en", tok)
+//	m := goshopee.NewAttributeMapper(res.Response.AttributeList)
+//	m.AddSynonyms("grey", "gray")
+//	mapping := m.Map(map[string][]string{"Colour": {"Gray"}, "Weight": {"0.5 kg"}})
+//	item.AttributeList = mapping.Attributes
+//
+// Attribute and value names are matched with their original and display
+// names, ignoring case, spaces, dashes and underscores. Quantities like
+// "0.5 kg" are converted to the units of the attribute.
+type AttributeMapper struct {
+	Attributes []Attribute
+
+	synonyms map[string][]string
+}
+
+// NewAttributeMapper returns a mapper to attrs, the attributes of a category
+func NewAttributeMapper(attrs []Attribute) *AttributeMapper {
+	return &AttributeMapper{Attributes: attrs, synonyms: map[string][]string{}}
+}
+
+// AddSynonyms makes the attribute or value names of words match each other
+func (m *AttributeMapper) AddSynonyms(words ...string) {
+	if m.synonyms == nil {
+		m.synonyms = map[string][]string{}
+	}
+	group := make([]string, 0, len(words))
+	for _, w := range words {
+		group = append(group, normalizeName(w))
+	}
+	for _, w := range group {
+		m.synonyms[w] = append(m.synonyms[w], group...)
+	}
+}
+
+// names returns s normalized and its synonyms
+func (m *AttributeMapper) names(s string) []string {
+	n := normalizeName(s)
+	if syns, ok := m.synonyms[n]; ok {
+		return syns
+	}
+	return []string{n}
+}
+
+func (m *AttributeMapper) matches(names []string, candidates ...string) bool {
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		c = normalizeName(c)
+		for _, n := range names {
+			if n == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Attribute returns the attribute named name
+func (m *AttributeMapper) Attribute(name string) (Attribute, bool) {
+	names := m.names(name)
+	for _, a := range m.Attributes {
+		if m.matches(names, a.OriginalAttributeName, a.DisplayAttributeName) {
+			return a, true
+		}
+	}
+	return Attribute{}, false
+}
+
+// Map maps src, the values of each attribute name, to the attributes of the
+// category, in the order of Attributes
+func (m *AttributeMapper) Map(src map[string][]string) *AttributeMapping {
+	res := new(AttributeMapping)
+	mapped := map[uint64][]ItemAttributeValue{}
+	names := make([]string, 0, len(src))
+	for name := range src {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		texts := src[name]
+		attr, ok := m.Attribute(name)
+		if !ok {
+			for _, text := range texts {
+				res.Unmapped = append(res.Unmapped, UnmappedValue{Attribute: name, Value: text, Err: ErrUnknownAttribute})
+			}
+			continue
+		}
+		for _, text := range texts {
+			if len(mapped[attr.AttributeID]) > 0 && !attr.multiple() {
+				res.Unmapped = append(res.Unmapped, UnmappedValue{Attribute: name, AttributeID: attr.AttributeID, Value: text, Err: ErrSingleValue})
+				continue
+			}
+			v, err := m.MapValue(attr, text)
+			if err != nil {
+				res.Unmapped = append(res.Unmapped, UnmappedValue{Attribute: name, AttributeID: attr.AttributeID, Value: text, Err: err})
+				continue
+			}
+			mapped[attr.AttributeID] = append(mapped[attr.AttributeID], v)
+		}
+	}
+
+	for _, attr := range m.Attributes {
+		if values := mapped[attr.AttributeID]; len(values) > 0 {
+			res.Attributes = append(res.Attributes, ItemAttribute{AttributeID: attr.AttributeID, AttributeValueList: values})
+		} else if attr.IsMandatory {
+			res.Missing = append(res.Missing, attr)
+		}
+	}
+	return res
+}
+
+// MapValue maps text to a value of attr, or to a custom value when the input
+// type of attr allows it
+func (m *AttributeMapper) MapValue(attr Attribute, text string) (ItemAttributeValue, error) {
+	names := m.names(text)
+	for _, v := range attr.AttributeValueList {
+		if m.matches(names, v.OriginalValueName, v.DisplayValueName) {
+			return ItemAttributeValue{ValueId: v.ValueID}, nil
+		}
+	}
+
+	// an ambiguous number only fails when it has to be converted to a unit
+	q, isQuantity, qerr := parseQuantity(text)
+	if isQuantity && q.unit != "" {
+		for _, v := range attr.AttributeValueList {
+			if vq, ok := attributeValueQuantity(v); ok && q.equals(vq) {
+				return ItemAttributeValue{ValueId: v.ValueID}, nil
+			}
+		}
+	}
+
+	if !attr.custom() {
+		return ItemAttributeValue{}, fmt.Errorf("%w: %q for attribute %d", ErrNoAttributeValue, text, attr.AttributeID)
+	}
+	v := ItemAttributeValue{OriginalValueName: strings.TrimSpace(text)}
+	if units := attr.units(); len(units) > 0 {
+		if qerr != nil {
+			return ItemAttributeValue{}, fmt.Errorf("%w: %q for attribute %d: %s", ErrNoAttributeValue, text, attr.AttributeID, qerr)
+		}
+		if !isQuantity || q.unit == "" {
+			return ItemAttributeValue{}, fmt.Errorf("%w: %q has no unit, expected one of %s", ErrAttributeUnit, text, strings.Join(units, ", "))
+		}
+		unit, value, ok := q.convert(units)
+		if !ok {
+			return ItemAttributeValue{}, fmt.Errorf("%w: %q can't be converted to %s", ErrAttributeUnit, text, strings.Join(units, ", "))
+		}
+		v.OriginalValueName = formatQuantity(value)
+		v.ValueUnit = unit
+	}
+	if v.OriginalValueName == "" {
+		return ItemAttributeValue{}, fmt.Errorf("%w: empty value for attribute %d", ErrNoAttributeValue, attr.AttributeID)
+	}
+	if err := checkAttributeFormat(attr, v.OriginalValueName); err != nil {
+		return ItemAttributeValue{}, fmt.Errorf("%w: attribute %d: %s", ErrNoAttributeValue, attr.AttributeID, err)
+	}
+	return v, nil
+}
+
+func (a Attribute) multiple() bool {
+	return a.InputType == AttributeInputMultipleSelect || a.InputType == AttributeInputMultipleSelectComboBox
+}
+
+func (a Attribute) custom() bool {
+	return a.InputType == AttributeInputTextField || a.InputType == AttributeInputComboBox ||
+		a.InputType == AttributeInputMultipleSelectComboBox
+}
+
+// normalizeName lowers s the same in every locale and collapses its spaces,
+// dashes and underscores
+func normalizeName(s string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.TrimSpace(s) {
+		if unicode.IsSpace(r) || r == '-' || r == '_' {
+			sep = true
+			continue
+		}
+		if sep && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		sep = false
+		switch r {
+		case 'İ', 'ı': // Turkish i, lowered to i in every other locale
+			r = 'i'
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+type dimension int
+
+const (
+	dimensionMass dimension = iota + 1
+	dimensionLength
+	dimensionVolume
+	dimensionData
+)
+
+// units of the quantities, in g, cm, ml and MB
+var quantityUnits = map[string]struct {
+	dim    dimension
+	factor float64
+}{
+	"mg": {dimensionMass, 0.001}, "g": {dimensionMass, 1}, "kg": {dimensionMass, 1000},
+	"oz": {dimensionMass, 28.349523125}, "lb": {dimensionMass, 453.59237}, "lbs": {dimensionMass, 453.59237},
+	"mm": {dimensionLength, 0.1}, "cm": {dimensionLength, 1}, "m": {dimensionLength, 100},
+	"in": {dimensionLength, 2.54}, "inch": {dimensionLength, 2.54}, "ft": {dimensionLength, 30.48},
+	"ml": {dimensionVolume, 1}, "l": {dimensionVolume, 1000}, "fl oz": {dimensionVolume, 29.5735295625},
+	"mb": {dimensionData, 1}, "gb": {dimensionData, 1024}, "tb": {dimensionData, 1024 * 1024},
+}
+
+var (
+	quantityRE  = regexp.MustCompile(`^\s*([-+]?[0-9](?:[0-9.,]*[0-9])?)\s*([^0-9\s.,].*?)?\s*$`)
+	thousandsRE = regexp.MustCompile(`^[-+]?[1-9][0-9]{0,2}(?:,[0-9]{3})+(?:\.[0-9]+)?$`)
+)
+
+type quantity struct {
+	value float64
+	unit  string // as written
+}
+
+// parseQuantity reads a number and an optional unit. ok is false for a text
+// that is not a quantity, err is set for a number that can't be read safely.
+func parseQuantity(s string) (q quantity, ok bool, err error) {
+	m := quantityRE.FindStringSubmatch(s)
+	if m == nil {
+		return quantity{}, false, nil
+	}
+	v, ok, err := parseNumber(m[1])
+	if !ok || err != nil {
+		return quantity{}, false, err
+	}
+	return quantity{value: v, unit: m[2]}, true, nil
+}
+
+// parseNumber reads 2.5, 2,5, 1,000 and 1,000.5. A comma is a decimal point
+// only in a number without "." where it is not followed by exactly three
+// digits. Commas grouping thousands are dropped, any other comma is
+// ambiguous: 1234,567 or 1.000,5 are rejected.
+func parseNumber(s string) (float64, bool, error) {
+	switch i := strings.IndexByte(s, ','); {
+	case i < 0:
+	case thousandsRE.MatchString(s):
+		s = strings.ReplaceAll(s, ",", "")
+	case strings.Count(s, ",") == 1 && !strings.Contains(s, ".") && len(s)-i-1 != 3:
+		s = strings.Replace(s, ",", ".", 1)
+	default:
+		return 0, false, fmt.Errorf("ambiguous number %s", s)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil, nil
+}
+
+// attributeValueQuantity returns the quantity of a value like "500" with
+// unit "g", or "500g"
+func attributeValueQuantity(v AttributeValue) (quantity, bool) {
+	q, ok, err := parseQuantity(v.OriginalValueName)
+	if !ok || err != nil {
+		return quantity{}, false
+	}
+	if q.unit == "" {
+		q.unit = v.ValueUnit
+	}
+	return q, q.unit != ""
+}
+
+// convert returns q in the unit of units it is written in, or else in the
+// first one of the same dimension
+func (q quantity) convert(units []string) (string, float64, bool) {
+	from, ok := quantityUnits[normalizeName(q.unit)]
+	for _, u := range units {
+		if normalizeName(u) == normalizeName(q.unit) {
+			return u, q.value, true
+		}
+	}
+	if !ok {
+		return "", 0, false
+	}
+	for _, u := range units {
+		if to, ok := quantityUnits[normalizeName(u)]; ok && to.dim == from.dim {
+			return u, q.value * from.factor / to.factor, true
+		}
+	}
+	return "", 0, false
+}
+
+func (q quantity) equals(o quantity) bool {
+	if normalizeName(q.unit) == normalizeName(o.unit) {
+		return almostEqual(q.value, o.value)
+	}
+	from, ok1 := quantityUnits[normalizeName(q.unit)]
+	to, ok2 := quantityUnits[normalizeName(o.unit)]
+	return ok1 && ok2 && from.dim == to.dim && almostEqual(q.value*from.factor, o.value*to.factor)
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// formatQuantity drops the float noise of unit conversions
+func formatQuantity(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
@@ -0,0 +1,138 @@
+package goshopee
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testAttributeMapper() *AttributeMapper {
+	m := NewAttributeMapper([]Attribute{
+		{AttributeID: 1, OriginalAttributeName: "Color", DisplayAttributeName: "Màu sắc", IsMandatory: true,
+			InputType: AttributeInputMultipleSelect, AttributeValueList: []AttributeValue{
+				{ValueID: 11, OriginalValueName: "Gray", DisplayValueName: "Xám"},
+				{ValueID: 12, OriginalValueName: "Light Blue", DisplayValueName: "Xanh nhạt"},
+			}},
+		{AttributeID: 2, OriginalAttributeName: "Net Weight", InputType: AttributeInputTextField,
+			InputValidationType: AttributeValidationFloat, AttributeUnit: []string{"g", "kg"}},
+		{AttributeID: 3, OriginalAttributeName: "Volume", InputType: AttributeInputDropDown,
+			AttributeValueList: []AttributeValue{
+				{ValueID: 31, OriginalValueName: "500", ValueUnit: "ml"},
+				{ValueID: 32, OriginalValueName: "1L"},
+			}},
+		{AttributeID: 4, OriginalAttributeName: "Warranty Duration", InputType: AttributeInputComboBox,
+			InputValidationType: AttributeValidationInt, IsMandatory: true,
+			AttributeValueList: []AttributeValue{{ValueID: 41, OriginalValueName: "12 Months"}}},
+		{AttributeID: 5, OriginalAttributeName: "Material", InputType: AttributeInputDropDown, IsMandatory: true,
+			AttributeValueList: []AttributeValue{{ValueID: 51, OriginalValueName: "Cotton"}}},
+		{AttributeID: 6, OriginalAttributeName: "Model", InputType: AttributeInputTextField},
+	})
+	m.AddSynonyms("grey", "gray")
+	m.AddSynonyms("colour", "color")
+	return m
+}
+
+func Test_AttributeMapperMapValue(t *testing.T) {
+	m := testAttributeMapper()
+	attr := func(name string) Attribute {
+		a, ok := m.Attribute(name)
+		if !ok {
+			t.Fatalf("attribute %q not found", name)
+		}
+		return a
+	}
+
+	cases := []struct {
+		attr     string
+		text     string
+		expected ItemAttributeValue
+	}{
+		{"color", "LIGHT-BLUE", ItemAttributeValue{ValueId: 12}},
+		{"Color", "xanh  nhạt", ItemAttributeValue{ValueId: 12}},
+		{"COLOUR", "grey", ItemAttributeValue{ValueId: 11}},
+		{"màu sắc", "XÁM", ItemAttributeValue{ValueId: 11}},
+		{"net_weight", "250 g", ItemAttributeValue{OriginalValueName: "250", ValueUnit: "g"}},
+		{"net weight", "1.5lb", ItemAttributeValue{OriginalValueName: "680.388555", ValueUnit: "g"}},
+		{"net weight", "0,5 KG", ItemAttributeValue{OriginalValueName: "0.5", ValueUnit: "kg"}},
+		{"net weight", "2,5 kg", ItemAttributeValue{OriginalValueName: "2.5", ValueUnit: "kg"}},
+		{"net weight", "1,000 g", ItemAttributeValue{OriginalValueName: "1000", ValueUnit: "g"}},
+		{"net weight", "1,250.5g", ItemAttributeValue{OriginalValueName: "1250.5", ValueUnit: "g"}},
+		{"volume", "1,000 ml", ItemAttributeValue{ValueId: 32}},
+		{"volume", "0.5 l", ItemAttributeValue{ValueId: 31}},
+		{"volume", "1000ml", ItemAttributeValue{ValueId: 32}},
+		{"warranty duration", "12 months", ItemAttributeValue{ValueId: 41}},
+		{"warranty duration", "24", ItemAttributeValue{OriginalValueName: "24"}},
+		{"model", "1,2,3 Series", ItemAttributeValue{OriginalValueName: "1,2,3 Series"}},
+		{"model", "1234,567", ItemAttributeValue{OriginalValueName: "1234,567"}},
+	}
+	for _, c := range cases {
+		v, err := m.MapValue(attr(c.attr), c.text)
+		if err != nil || v != c.expected {
+			t.Errorf("MapValue(%s, %q) returned %+v, %v, expected %+v", c.attr, c.text, v, err, c.expected)
+		}
+	}
+
+	errCases := []struct {
+		attr string
+		text string
+		err  error
+	}{
+		{"color", "Red", ErrNoAttributeValue},
+		{"volume", "2 l", ErrNoAttributeValue},
+		{"net weight", "250", ErrAttributeUnit},
+		{"net weight", "25 cm", ErrAttributeUnit},
+		{"net weight", "heavy", ErrAttributeUnit},
+		{"net weight", "1234,567 g", ErrNoAttributeValue},
+		{"net weight", "1.000,5 g", ErrNoAttributeValue},
+		{"net weight", "0,500 kg", ErrNoAttributeValue},
+		{"warranty duration", "two years", ErrNoAttributeValue},
+	}
+	for _, c := range errCases {
+		if _, err := m.MapValue(attr(c.attr), c.text); !errors.Is(err, c.err) {
+			t.Errorf("MapValue(%s, %q) returned %v, expected %v", c.attr, c.text, err, c.err)
+		}
+	}
+}
+
+func Test_AttributeMapperMap(t *testing.T) {
+	m := testAttributeMapper()
+	res := m.Map(map[string][]string{
+		"Colour":     {"Grey", "Light Blue", "Red"},
+		"Net Weight": {"0.2kg", "300g"},
+		"Size":       {"XL"},
+	})
+
+	expected := []ItemAttribute{
+		{AttributeID: 1, AttributeValueList: []ItemAttributeValue{{ValueId: 11}, {ValueId: 12}}},
+		{AttributeID: 2, AttributeValueList: []ItemAttributeValue{{OriginalValueName: "0.2", ValueUnit: "kg"}}},
+	}
+	if !reflect.DeepEqual(res.Attributes, expected) {
+		t.Errorf("Map returned attributes %+v, expected %+v", res.Attributes, expected)
+	}
+
+	var reasons []error
+	for _, u := range res.Unmapped {
+		reasons = append(reasons, u.Err)
+	}
+	if len(reasons) != 3 || !errors.Is(reasons[0], ErrNoAttributeValue) ||
+		!errors.Is(reasons[1], ErrSingleValue) || !errors.Is(reasons[2], ErrUnknownAttribute) {
+		t.Errorf("Map returned unmapped %+v", res.Unmapped)
+	}
+
+	var missing []uint64
+	for _, a := range res.Missing {
+		missing = append(missing, a.AttributeID)
+	}
+	if !reflect.DeepEqual(missing, []uint64{4, 5}) {
+		t.Errorf("Map returned missing %v, expected [4 5]", missing)
+	}
+
+	// the mapped attributes pass the validation of the category
+	rules := &ItemRules{Attributes: m.Attributes}
+	item := AddItemRequest{ItemBase: ItemBase{AttributeList: res.Attributes}}
+	for _, v := range rules.Check(item) {
+		if v.Code != ViolationMissingAttribute && v.Field != "weight" && v.Field != "logistic_info" {
+			t.Errorf("mapped attributes violate %s", v)
+		}
+	}
+}
@@ -1,84 +1,117 @@
 package goshopee
 
-import "fmt"
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
 
 // https://open.shopee.com/documents?module=87&type=2&id=58&version=2
 type AuthService interface {
-	GetAuthURL() (string,error)
-	GetCancelAuthURL() (string,error)
-	GetAccessToken(uint64,uint64,string) (*AccessTokenResponse,error)
-	RefreshAccessToken(uint64,uint64,string) (*RefreshAccessTokenResponse,error)
+	GetAuthURL() (string, error)
+	GetCancelAuthURL() (string, error)
+	GetAccessToken(uint64, uint64, string) (*AccessTokenResponse, error)
+	GetAccessTokenWithContext(context.Context, uint64, uint64, string) (*AccessTokenResponse, error)
+	RefreshAccessToken(uint64, uint64, string) (*RefreshAccessTokenResponse, error)
+	RefreshAccessTokenWithContext(context.Context, uint64, uint64, string) (*RefreshAccessTokenResponse, error)
 }
 
 type AccessTokenResponse struct {
 	BaseResponse
 
-	AccessToken string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	ExpireIn int `json:"expire_in"`
+	AccessToken    string   `json:"access_token"`
+	RefreshToken   string   `json:"refresh_token"`
+	ExpireIn       int      `json:"expire_in"`
 	MerchantIDList []uint64 `json:"merchant_id_list,omitempty"`
-	ShopIDList []uint64 `json:"shop_id_list,omitempty"`
+	ShopIDList     []uint64 `json:"shop_id_list,omitempty"`
 }
 
 type RefreshAccessTokenResponse struct {
 	BaseResponse
 
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
-	ExpireIn int `json:"expire_in"`
-	PartnerID uint64 `json:"partner_id"`
-	MerchantID uint64 `json:"merchant_id"`
-	ShopID uint64 `json:"shop_id"`
+	ExpireIn     int    `json:"expire_in"`
+	PartnerID    uint64 `json:"partner_id"`
+	MerchantID   uint64 `json:"merchant_id"`
+	ShopID       uint64 `json:"shop_id"`
 }
 
 type AuthServiceOp struct {
 	client *Client
 }
 
-func (s *AuthServiceOp)GetAuthURL() (string,error) {
-	rurl := s.client.app.RedirectURL
-	path:="/api/v2/shop/auth_partner"
-	sign,ts,_ := s.client.Util.Sign(path)
-	aurl := fmt.Sprintf("%s%s?partner_id=%d&timestamp=%d&sign=%s&redirect=%s", s.client.app.APIURL,path, s.client.app.PartnerID,ts,sign, rurl)
-	return aurl,nil
+// GetAuthURL returns the url of the page where a seller authorizes the app,
+// redirecting back to App.RedirectURL
+func (s *AuthServiceOp) GetAuthURL() (string, error) {
+	return s.client.partnerURL(authPartnerPath, s.client.app.RedirectURL)
+}
+
+// GetCancelAuthURL returns the url of the page where a seller revokes the
+// authorization of the app, redirecting back to App.RedirectURL
+func (s *AuthServiceOp) GetCancelAuthURL() (string, error) {
+	return s.client.partnerURL(cancelAuthPartnerPath, s.client.app.RedirectURL)
 }
 
-func (s *AuthServiceOp)GetCancelAuthURL() (string,error) {
-	rurl := s.client.app.RedirectURL
-	path:="/api/v2/shop/cancel_auth_partner"
-	sign,ts,_ := s.client.Util.Sign(path)
-	aurl := fmt.Sprintf("%s%s?partner_id=%d&timestamp=%d&sign=%s&redirect=%s", s.client.app.APIURL,path, s.client.app.PartnerID,ts,sign, rurl)
-	return aurl,nil
+const (
+	authPartnerPath       = "/api/v2/shop/auth_partner"
+	cancelAuthPartnerPath = "/api/v2/shop/cancel_auth_partner"
+)
+
+// partnerURL returns the signed url of the page path, with redirect escaped
+func (c *Client) partnerURL(path, redirect string) (string, error) {
+	u, err := url.Parse(strings.TrimRight(c.app.APIURL, "/") + path)
+	if err != nil {
+		return "", err
+	}
+	sign, ts := c.signer.SignPublic(path)
+	q := url.Values{}
+	q.Set("partner_id", strconv.Itoa(c.app.PartnerID))
+	q.Set("timestamp", strconv.FormatInt(ts, 10))
+	q.Set("sign", sign)
+	q.Set("redirect", redirect)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
 }
 
-func (s *AuthServiceOp)GetAccessToken(sid uint64, aid uint64, code string) (*AccessTokenResponse,error){
+func (s *AuthServiceOp) GetAccessToken(sid uint64, aid uint64, code string) (*AccessTokenResponse, error) {
+	return s.GetAccessTokenWithContext(context.Background(), sid, aid, code)
+}
+
+func (s *AuthServiceOp) GetAccessTokenWithContext(ctx context.Context, sid uint64, aid uint64, code string) (*AccessTokenResponse, error) {
 	path := "/auth/token/get"
 	params := map[string]interface{}{
 		"code": code,
 	}
-	if sid!=0{
-		params["shop_id"]=sid
-	}else if aid!=0{
-		params["main_account_id"]=aid
+	if sid != 0 {
+		params["shop_id"] = sid
+	} else if aid != 0 {
+		params["main_account_id"] = aid
 	}
 
 	resp := new(AccessTokenResponse)
-	err := s.client.Post(path, params, resp)
+	err := s.client.PostWithContext(ctx, path, params, resp)
 	return resp, err
 }
 
-func (s *AuthServiceOp)RefreshAccessToken(sid uint64, aid uint64, refresh string) (*RefreshAccessTokenResponse,error){
+func (s *AuthServiceOp) RefreshAccessToken(sid uint64, mid uint64, refresh string) (*RefreshAccessTokenResponse, error) {
+	return s.RefreshAccessTokenWithContext(context.Background(), sid, mid, refresh)
+}
+
+// RefreshAccessTokenWithContext refreshes the token of shop sid or merchant mid.
+func (s *AuthServiceOp) RefreshAccessTokenWithContext(ctx context.Context, sid uint64, mid uint64, refresh string) (*RefreshAccessTokenResponse, error) {
 	path := "/auth/access_token/get"
 	params := map[string]interface{}{
 		"refresh_token": refresh,
 	}
-	if sid!=0{
-		params["shop_id"]=sid
-	}else if aid!=0{
-		params["main_account_id"]=aid
+	if sid != 0 {
+		params["shop_id"] = sid
+	} else if mid != 0 {
+		params["merchant_id"] = mid
 	}
 
 	resp := new(RefreshAccessTokenResponse)
-	err := s.client.Post(path, params, resp)
+	err := s.client.PostWithContext(ctx, path, params, resp)
 	return resp, err
 }
@@ -0,0 +1,292 @@
+package goshopee
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidState is returned for an auth callback whose state was not
+	// issued by the AuthHandler, expired or was already used
+	ErrInvalidState = errors.New("invalid auth state")
+	// ErrInvalidCallback is returned for an auth callback missing its code,
+	// shop_id or main_account_id
+	ErrInvalidCallback = errors.New("invalid auth callback")
+)
+
+// DefaultAuthStateTTL is how long an auth url made by AuthHandler stays
+// usable
+const DefaultAuthStateTTL = 10 * time.Minute
+
+// cancel states are told apart by their prefix, they are random otherwise
+const cancelStatePrefix = "cancel-"
+
+// AuthStateStore keeps the states of the pending authorizations, so each
+// callback is accepted once. Implementations must be safe for concurrent
+// use.
+type AuthStateStore interface {
+	// SaveState stores state until expireAt
+	SaveState(ctx context.Context, state string, expireAt time.Time) error
+	// TakeState removes state, reporting whether it was stored and not
+	// expired
+	TakeState(ctx context.Context, state string) (bool, error)
+}
+
+// MemoryAuthStateStore keeps auth states in memory
+type MemoryAuthStateStore struct {
+	mu     sync.Mutex
+	states map[string]time.Time
+}
+
+// NewMemoryAuthStateStore returns an empty MemoryAuthStateStore
+func NewMemoryAuthStateStore() *MemoryAuthStateStore {
+	return &MemoryAuthStateStore{states: map[string]time.Time{}}
+}
+
+func (s *MemoryAuthStateStore) SaveState(ctx context.Context, state string, expireAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, exp := range s.states {
+		if !exp.After(now) {
+			delete(s.states, k)
+		}
+	}
+	s.states[state] = expireAt
+	return nil
+}
+
+func (s *MemoryAuthStateStore) TakeState(ctx context.Context, state string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exp, ok := s.states[state]
+	delete(s.states, state)
+	return ok && exp.After(time.Now()), nil
+}
+
+// AuthResult is a completed authorization. A seller authorizing with a main
+// account grants every shop of ShopIDList and merchant of MerchantIDList at
+// once, all with Token.
+type AuthResult struct {
+	ShopID         uint64 // set when a single shop authorized
+	MainAccountID  uint64 // set when a main account authorized
+	ShopIDList     []uint64
+	MerchantIDList []uint64
+	Token          *Token
+}
+
+// Keys returns the keys the token was stored with
+func (r *AuthResult) Keys() []TokenKey {
+	var keys []TokenKey
+	for _, sid := range r.ShopIDList {
+		keys = append(keys, ShopTokenKey(sid))
+	}
+	for _, mid := range r.MerchantIDList {
+		keys = append(keys, MerchantTokenKey(mid))
+	}
+	if len(keys) == 0 && r.ShopID != 0 {
+		keys = append(keys, ShopTokenKey(r.ShopID))
+	}
+	return keys
+}
+
+// AuthHandler serves the redirect url of the app. Shopee sends the seller
+// there after authorization, with code and shop_id, or main_account_id for a
+// main account. The handler checks the state it put in the redirect url,
+// exchanges the code for tokens and saves them to the TokenStore:
+//
+//	h := goshopee.NewAuthHandler(client, store)
+//	h.OnAuthorized = func(w http.ResponseWriter, r *http.Request, res *goshopee.AuthResult) {
+//		http.Redirect(w, r, "/shops", http.StatusFound)
+//	}
+//	http.Handle("/shopee/callback", h)
+//
+//	u, err := h.AuthURL(ctx) // send the seller there
+type AuthHandler struct {
+	client *Client
+	store  TokenStore
+
+	// States keeps the pending states, a MemoryAuthStateStore by default.
+	// Use a shared store when several processes serve the redirect url.
+	States AuthStateStore
+
+	// StateTTL is how long an auth url stays usable, DefaultAuthStateTTL
+	// when 0
+	StateTTL time.Duration
+
+	// OnAuthorized answers the seller once the tokens are stored, with a
+	// plain text page when nil
+	OnAuthorized func(w http.ResponseWriter, r *http.Request, res *AuthResult)
+
+	// OnCancelled answers the seller coming back from CancelAuthURL, with a
+	// plain text page when nil
+	OnCancelled func(w http.ResponseWriter, r *http.Request)
+
+	// OnError answers a failed callback, with http.Error when nil. err wraps
+	// ErrInvalidState or ErrInvalidCallback for bad requests.
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// NewAuthHandler returns a handler exchanging codes with client and saving
+// the tokens to store. App.RedirectURL of the client must point to it.
+func NewAuthHandler(client *Client, store TokenStore) *AuthHandler {
+	return &AuthHandler{client: client, store: store, States: NewMemoryAuthStateStore()}
+}
+
+// AuthURL returns a url where a seller authorizes the app, usable once
+// within StateTTL
+func (h *AuthHandler) AuthURL(ctx context.Context) (string, error) {
+	return h.partnerURL(ctx, authPartnerPath, "")
+}
+
+// CancelAuthURL returns a url where a seller revokes the authorization of
+// the app, usable once within StateTTL
+func (h *AuthHandler) CancelAuthURL(ctx context.Context) (string, error) {
+	return h.partnerURL(ctx, cancelAuthPartnerPath, cancelStatePrefix)
+}
+
+func (h *AuthHandler) partnerURL(ctx context.Context, path, prefix string) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	state := prefix + hex.EncodeToString(nonce)
+
+	ttl := h.StateTTL
+	if ttl == 0 {
+		ttl = DefaultAuthStateTTL
+	}
+	if err := h.States.SaveState(ctx, state, time.Now().Add(ttl)); err != nil {
+		return "", err
+	}
+
+	redirect, err := url.Parse(h.client.app.RedirectURL)
+	if err != nil {
+		return "", err
+	}
+	q := redirect.Query()
+	q.Set("state", state)
+	redirect.RawQuery = q.Encode()
+	return h.client.partnerURL(path, redirect.String())
+}
+
+func (h *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	cancelled := strings.HasPrefix(state, cancelStatePrefix)
+	res, err := h.Callback(r.Context(), r.URL.Query())
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+
+	if cancelled {
+		if h.OnCancelled != nil {
+			h.OnCancelled(w, r)
+			return
+		}
+		fmt.Fprintln(w, "Shopee authorization cancelled.")
+		return
+	}
+	if h.OnAuthorized != nil {
+		h.OnAuthorized(w, r, res)
+		return
+	}
+	fmt.Fprintln(w, "Shopee authorization completed.")
+}
+
+// Callback handles the query of a redirect to the app, for use outside of
+// ServeHTTP. The result is nil for the redirect after a cancellation.
+func (h *AuthHandler) Callback(ctx context.Context, q url.Values) (*AuthResult, error) {
+	state := q.Get("state")
+	if state == "" {
+		return nil, fmt.Errorf("%w: missing state", ErrInvalidState)
+	}
+	ok, err := h.States.TakeState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidState
+	}
+	if strings.HasPrefix(state, cancelStatePrefix) {
+		return nil, nil
+	}
+
+	res, err := parseAuthCallback(q)
+	if err != nil {
+		return nil, err
+	}
+	code := q.Get("code")
+	tok, err := h.client.Auth.GetAccessTokenWithContext(ctx, res.ShopID, res.MainAccountID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Token = tok.Token()
+	res.ShopIDList = tok.ShopIDList
+	res.MerchantIDList = tok.MerchantIDList
+	for _, key := range res.Keys() {
+		if err := h.store.SetToken(ctx, key, res.Token); err != nil {
+			return nil, fmt.Errorf("error to store token of %s: %w", key, err)
+		}
+	}
+	return res, nil
+}
+
+// parseAuthCallback validates the code, shop_id and main_account_id of the
+// redirect query q
+func parseAuthCallback(q url.Values) (*AuthResult, error) {
+	if q.Get("code") == "" {
+		return nil, fmt.Errorf("%w: missing code", ErrInvalidCallback)
+	}
+
+	res := new(AuthResult)
+	sid, aid := q.Get("shop_id"), q.Get("main_account_id")
+	switch {
+	case sid != "" && aid != "":
+		return nil, fmt.Errorf("%w: both shop_id and main_account_id", ErrInvalidCallback)
+	case sid != "":
+		id, err := strconv.ParseUint(sid, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("%w: invalid shop_id %q", ErrInvalidCallback, sid)
+		}
+		res.ShopID = id
+	case aid != "":
+		id, err := strconv.ParseUint(aid, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("%w: invalid main_account_id %q", ErrInvalidCallback, aid)
+		}
+		res.MainAccountID = id
+	default:
+		return nil, fmt.Errorf("%w: missing shop_id or main_account_id", ErrInvalidCallback)
+	}
+	return res, nil
+}
+
+func (h *AuthHandler) fail(w http.ResponseWriter, r *http.Request, err error) {
+	if h.OnError != nil {
+		h.OnError(w, r, err)
+		return
+	}
+	status := http.StatusBadGateway
+	if errors.Is(err, ErrInvalidState) || errors.Is(err, ErrInvalidCallback) {
+		status = http.StatusBadRequest
+	}
+	http.Error(w, err.Error(), status)
+}
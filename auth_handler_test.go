@@ -0,0 +1,153 @@
+package goshopee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/passwind/go-shopee-v2/shopeetest"
+)
+
+// redirectState returns the state of the redirect of the auth url u
+func redirectState(t *testing.T, u string) string {
+	t.Helper()
+	pu, err := url.Parse(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redirect, err := url.Parse(pu.Query().Get("redirect"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return redirect.Query().Get("state")
+}
+
+func callback(h http.Handler, q url.Values) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/callback?"+q.Encode(), nil))
+	return w
+}
+
+func Test_AuthHandlerShop(t *testing.T) {
+	srv := shopeetest.NewServer(12345678, "hush")
+	defer srv.Close()
+	srv.AddShop(&shopeetest.Shop{ShopID: shopID, Name: "sandbox"})
+
+	c := NewClient(App{PartnerID: srv.PartnerID, PartnerKey: srv.PartnerKey, APIURL: srv.URL, RedirectURL: "https://example.com/callback?lang=en"})
+	store := NewMemoryTokenStore()
+	h := NewAuthHandler(c, store)
+	var authorized *AuthResult
+	h.OnAuthorized = func(w http.ResponseWriter, r *http.Request, res *AuthResult) { authorized = res }
+
+	authURL, err := h.AuthURL(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pu, _ := url.Parse(authURL)
+	if err := c.signer.VerifyURL(pu); err != nil {
+		t.Errorf("auth url %s: %s", authURL, err)
+	}
+	redirect, _ := url.Parse(pu.Query().Get("redirect"))
+	if redirect.Query().Get("lang") != "en" {
+		t.Errorf("redirect %s lost its query", redirect)
+	}
+	state := redirectState(t, authURL)
+
+	q := url.Values{"code": {srv.AuthCode(shopID)}, "shop_id": {fmt.Sprint(shopID)}, "state": {state}}
+	if w := callback(h, q); w.Code != http.StatusOK {
+		t.Fatalf("callback returned %d %s", w.Code, w.Body)
+	}
+	if authorized == nil || authorized.ShopID != shopID {
+		t.Fatalf("OnAuthorized called with %+v", authorized)
+	}
+	tok, err := store.GetToken(context.Background(), ShopTokenKey(shopID))
+	if err != nil || tok.AccessToken != authorized.Token.AccessToken {
+		t.Errorf("stored token %+v, %v", tok, err)
+	}
+	if _, err := c.Shop.GetProfile(shopID, tok.AccessToken); err != nil {
+		t.Errorf("stored token rejected: %s", err)
+	}
+
+	// a state is used once
+	if w := callback(h, q); w.Code != http.StatusBadRequest {
+		t.Errorf("replayed callback returned %d", w.Code)
+	}
+}
+
+func Test_AuthHandlerInvalidCallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	h := NewAuthHandler(client, NewMemoryTokenStore())
+	cases := []url.Values{
+		{"code": {"c"}, "shop_id": {"1"}},
+		{"code": {"c"}, "shop_id": {"1"}, "state": {"forged"}},
+	}
+	for _, q := range cases {
+		if w := callback(h, q); w.Code != http.StatusBadRequest {
+			t.Errorf("callback %s returned %d", q.Encode(), w.Code)
+		}
+	}
+
+	for _, q := range []url.Values{
+		{"shop_id": {"1"}},
+		{"code": {"c"}},
+		{"code": {"c"}, "shop_id": {"x"}},
+		{"code": {"c"}, "shop_id": {"1"}, "main_account_id": {"2"}},
+	} {
+		u, _ := h.AuthURL(context.Background())
+		q.Set("state", redirectState(t, u))
+		if w := callback(h, q); w.Code != http.StatusBadRequest {
+			t.Errorf("callback %s returned %d", q.Encode(), w.Code)
+		}
+	}
+	if n := httpmock.GetTotalCallCount(); n != 0 {
+		t.Errorf("%d requests sent for invalid callbacks", n)
+	}
+}
+
+func Test_AuthHandlerMainAccount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	app := app
+	app.RedirectURL = "https://example.com/callback?lang=en"
+	c := NewClient(app)
+	httpmock.ActivateNonDefault(c.Client)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/auth/token/get", app.APIURL),
+		httpmock.NewStringResponder(200, `{"access_token":"acc","refresh_token":"ref","expire_in":14400,"shop_id_list":[11,12],"merchant_id_list":[21]}`))
+
+	store := NewMemoryTokenStore()
+	h := NewAuthHandler(c, store)
+	u, _ := h.AuthURL(context.Background())
+	q := url.Values{"code": {"c"}, "main_account_id": {"5"}, "state": {redirectState(t, u)}}
+	if w := callback(h, q); w.Code != http.StatusOK {
+		t.Fatalf("callback returned %d %s", w.Code, w.Body)
+	}
+
+	for _, key := range []TokenKey{ShopTokenKey(11), ShopTokenKey(12), MerchantTokenKey(21)} {
+		if tok, err := store.GetToken(context.Background(), key); err != nil || tok.AccessToken != "acc" {
+			t.Errorf("token of %s: %+v, %v", key, tok, err)
+		}
+	}
+}
+
+func Test_AuthHandlerCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	app := app
+	app.RedirectURL = "https://example.com/callback?lang=en"
+	h := NewAuthHandler(NewClient(app), NewMemoryTokenStore())
+	cancelled := false
+	h.OnCancelled = func(w http.ResponseWriter, r *http.Request) { cancelled = true }
+
+	u, _ := h.CancelAuthURL(context.Background())
+	if w := callback(h, url.Values{"state": {redirectState(t, u)}}); w.Code != http.StatusOK || !cancelled {
+		t.Errorf("cancel callback returned %d, cancelled %v", w.Code, cancelled)
+	}
+}
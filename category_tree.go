@@ -0,0 +1,344 @@
+package goshopee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CategoryPathSeparator joins the display names of a category path, as in
+// "Women Clothes > Dresses"
+const CategoryPathSeparator = " > "
+
+// DefaultCategoryTTL is how long CategoryCache keeps a tree. Shopee changes
+// its categories rarely.
+const DefaultCategoryTTL = 24 * time.Hour
+
+// CategoryNode is a category of a CategoryTree
+type CategoryNode struct {
+	Category
+
+	Parent   *CategoryNode
+	Children []*CategoryNode
+}
+
+// IsLeaf reports whether items can be listed in the category
+func (n *CategoryNode) IsLeaf() bool {
+	return !n.HasChildren && len(n.Children) == 0
+}
+
+// CategoryTree links the flat category list of GetCategory by parent
+type CategoryTree struct {
+	// Region and Language the categories were fetched for, informative
+	Region    string
+	Language  string
+	FetchedAt time.Time
+
+	list  []Category
+	roots []*CategoryNode
+	byID  map[uint64]*CategoryNode
+}
+
+// NewCategoryTree builds the tree of list. Categories whose parent is not in
+// list are roots.
+func NewCategoryTree(list []Category) *CategoryTree {
+	t := &CategoryTree{list: list, byID: make(map[uint64]*CategoryNode, len(list))}
+	for _, c := range list {
+		t.byID[c.CategoryID] = &CategoryNode{Category: c}
+	}
+	for _, c := range list {
+		n := t.byID[c.CategoryID]
+		if p, ok := t.byID[c.ParentCategoryID]; ok && p != n {
+			n.Parent = p
+			p.Children = append(p.Children, n)
+			continue
+		}
+		t.roots = append(t.roots, n)
+	}
+	return t
+}
+
+// Categories returns the flat category list of the tree
+func (t *CategoryTree) Categories() []Category {
+	return t.list
+}
+
+// Roots returns the top level categories
+func (t *CategoryTree) Roots() []*CategoryNode {
+	return t.roots
+}
+
+// Get returns the category id
+func (t *CategoryTree) Get(id uint64) (*CategoryNode, bool) {
+	n, ok := t.byID[id]
+	return n, ok
+}
+
+// Lookup returns the category at the display path, like "Women Clothes >
+// Dresses". Names are compared case insensitively.
+func (t *CategoryTree) Lookup(path string) (*CategoryNode, bool) {
+	names := strings.Split(path, strings.TrimSpace(CategoryPathSeparator))
+	nodes := t.roots
+	var found *CategoryNode
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		found = nil
+		for _, n := range nodes {
+			if strings.EqualFold(n.DisplayCategoryName, name) {
+				found = n
+				break
+			}
+		}
+		if found == nil {
+			return nil, false
+		}
+		nodes = found.Children
+	}
+	return found, found != nil
+}
+
+// Ancestors returns the parents of the category id, the root first
+func (t *CategoryTree) Ancestors(id uint64) []Category {
+	n, ok := t.byID[id]
+	if !ok {
+		return nil
+	}
+	var chain []Category
+	for p := n.Parent; p != nil; p = p.Parent {
+		chain = append([]Category{p.Category}, chain...)
+	}
+	return chain
+}
+
+// Path returns the display path of the category id, empty for an unknown
+// category
+func (t *CategoryTree) Path(id uint64) string {
+	n, ok := t.byID[id]
+	if !ok {
+		return ""
+	}
+	var names []string
+	for _, c := range t.Ancestors(id) {
+		names = append(names, c.DisplayCategoryName)
+	}
+	return strings.Join(append(names, n.DisplayCategoryName), CategoryPathSeparator)
+}
+
+// Leaves returns the categories items can be listed in, in the order of the
+// category list
+func (t *CategoryTree) Leaves() []Category {
+	var leaves []Category
+	for _, c := range t.list {
+		if t.byID[c.CategoryID].IsLeaf() {
+			leaves = append(leaves, c)
+		}
+	}
+	return leaves
+}
+
+type categoryTreeJSON struct {
+	Region       string     `json:"region,omitempty"`
+	Language     string     `json:"language,omitempty"`
+	FetchedAt    time.Time  `json:"fetched_at"`
+	CategoryList []Category `json:"category_list"`
+}
+
+func (t *CategoryTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(categoryTreeJSON{Region: t.Region, Language: t.Language, FetchedAt: t.FetchedAt, CategoryList: t.list})
+}
+
+func (t *CategoryTree) UnmarshalJSON(b []byte) error {
+	var v categoryTreeJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*t = *NewCategoryTree(v.CategoryList)
+	t.Region, t.Language, t.FetchedAt = v.Region, v.Language, v.FetchedAt
+	return nil
+}
+
+// Save writes the tree to the file at path
+func (t *CategoryTree) Save(path string) error {
+	byts, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, byts)
+}
+
+// LoadCategoryTree reads a tree written by Save
+func LoadCategoryTree(path string) (*CategoryTree, error) {
+	byts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t := new(CategoryTree)
+	if err := json.Unmarshal(byts, t); err != nil {
+		return nil, fmt.Errorf("error to decode category file %s: %s", path, err)
+	}
+	return t, nil
+}
+
+type categoryKey struct {
+	region   string
+	language string
+}
+
+// CategoryCache keeps the category tree of each region and language, since
+// every shop of a region shares its categories. It is safe for concurrent
+// use:
+//
+//	cache := goshopee.NewCategoryCache(client)
+//	cache.Dir = "/var/cache/shopee"
+//	tree, err := cache.Tree(ctx, sid, "SG", "en", tok)
+type CategoryCache struct {
+	Product ProductService
+
+	// TTL is how long a tree is used before it is fetched again,
+	// DefaultCategoryTTL when 0
+	TTL time.Duration
+
+	// Dir keeps the trees on disk across restarts when not empty
+	Dir string
+
+	// Now is the clock of the TTL, time.Now when nil
+	Now func() time.Time
+
+	// OnSaveError is called when a fetched tree can't be saved to Dir. The
+	// tree is still used. Optional.
+	OnSaveError func(region, lang string, err error)
+
+	mu       sync.Mutex
+	trees    map[categoryKey]*CategoryTree
+	fetching map[categoryKey]*categoryFetch
+}
+
+// categoryFetch is a fetch in flight, shared by the callers missing the same
+// tree
+type categoryFetch struct {
+	done chan struct{}
+	tree *CategoryTree
+	err  error
+}
+
+// NewCategoryCache returns an empty cache fetching categories with c
+func NewCategoryCache(c *Client) *CategoryCache {
+	return &CategoryCache{Product: c.Product}
+}
+
+func (cc *CategoryCache) now() time.Time {
+	if cc.Now == nil {
+		return time.Now()
+	}
+	return cc.Now()
+}
+
+func (cc *CategoryCache) fresh(t *CategoryTree) bool {
+	ttl := cc.TTL
+	if ttl == 0 {
+		ttl = DefaultCategoryTTL
+	}
+	return t != nil && cc.now().Sub(t.FetchedAt) < ttl
+}
+
+// Tree returns the categories of region in lang, fetched with the shop sid of
+// the region when they are not cached or expired. Concurrent calls missing
+// the same tree share one fetch.
+func (cc *CategoryCache) Tree(ctx context.Context, sid uint64, region, lang, tok string) (*CategoryTree, error) {
+	key := categoryKey{region: region, language: lang}
+
+	cc.mu.Lock()
+	if t := cc.trees[key]; cc.fresh(t) {
+		cc.mu.Unlock()
+		return t, nil
+	}
+	if f, ok := cc.fetching[key]; ok {
+		cc.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.tree, f.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	f := &categoryFetch{done: make(chan struct{})}
+	if cc.fetching == nil {
+		cc.fetching = map[categoryKey]*categoryFetch{}
+	}
+	cc.fetching[key] = f
+	cc.mu.Unlock()
+
+	f.tree, f.err = cc.fetch(ctx, sid, key, tok)
+
+	cc.mu.Lock()
+	if f.err == nil {
+		if cc.trees == nil {
+			cc.trees = map[categoryKey]*CategoryTree{}
+		}
+		cc.trees[key] = f.tree
+	}
+	delete(cc.fetching, key)
+	cc.mu.Unlock()
+	close(f.done)
+
+	return f.tree, f.err
+}
+
+// fetch loads the tree of key from Dir, or from shopee when it is missing or
+// expired there
+func (cc *CategoryCache) fetch(ctx context.Context, sid uint64, key categoryKey, tok string) (*CategoryTree, error) {
+	if cc.Dir != "" {
+		if t, err := LoadCategoryTree(cc.file(key)); err == nil && cc.fresh(t) {
+			return t, nil
+		}
+	}
+
+	res, err := cc.Product.GetCategoryWithContext(ctx, sid, key.language, tok)
+	if err != nil {
+		return nil, err
+	}
+	t := NewCategoryTree(res.Response.CategoryList)
+	t.Region, t.Language, t.FetchedAt = key.region, key.language, cc.now()
+
+	if cc.Dir != "" {
+		if err := t.Save(cc.file(key)); err != nil && cc.OnSaveError != nil {
+			cc.OnSaveError(key.region, key.language, fmt.Errorf("error to save categories of %s: %w", key.region, err))
+		}
+	}
+	return t, nil
+}
+
+// Invalidate drops the tree of region in lang, from the disk too
+func (cc *CategoryCache) Invalidate(region, lang string) error {
+	key := categoryKey{region: region, language: lang}
+	cc.mu.Lock()
+	delete(cc.trees, key)
+	cc.mu.Unlock()
+
+	if cc.Dir == "" {
+		return nil
+	}
+	if err := os.Remove(cc.file(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (cc *CategoryCache) file(key categoryKey) string {
+	name := func(s string) string {
+		if s == "" {
+			return "default"
+		}
+		return strings.ToLower(filepath.Base(s))
+	}
+	return filepath.Join(cc.Dir, fmt.Sprintf("categories_%s_%s.json", name(key.region), name(key.language)))
+}
@@ -0,0 +1,189 @@
+package goshopee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+var testCategories = []Category{
+	{CategoryID: 100, DisplayCategoryName: "Women Clothes", HasChildren: true},
+	{CategoryID: 101, ParentCategoryID: 100, DisplayCategoryName: "Dresses"},
+	{CategoryID: 102, ParentCategoryID: 100, DisplayCategoryName: "Tops", HasChildren: true},
+	{CategoryID: 103, ParentCategoryID: 102, DisplayCategoryName: "Tank Tops"},
+	{CategoryID: 200, DisplayCategoryName: "Mobile & Gadgets"},
+}
+
+func Test_CategoryTree(t *testing.T) {
+	tree := NewCategoryTree(testCategories)
+
+	if len(tree.Roots()) != 2 {
+		t.Errorf("Roots returned %d categories, expected 2", len(tree.Roots()))
+	}
+	if n, ok := tree.Get(102); !ok || len(n.Children) != 1 || n.Parent.CategoryID != 100 || n.IsLeaf() {
+		t.Errorf("Get(102) returned %+v", n)
+	}
+
+	n, ok := tree.Lookup("women clothes>Tops >  Tank Tops")
+	if !ok || n.CategoryID != 103 {
+		t.Errorf("Lookup returned %+v, %v", n, ok)
+	}
+	if _, ok := tree.Lookup("Women Clothes > Tank Tops"); ok {
+		t.Errorf("Lookup found a category out of its parent")
+	}
+
+	if p := tree.Path(103); p != "Women Clothes > Tops > Tank Tops" {
+		t.Errorf("Path returned %q", p)
+	}
+
+	var ids []uint64
+	for _, c := range tree.Ancestors(103) {
+		ids = append(ids, c.CategoryID)
+	}
+	if !reflect.DeepEqual(ids, []uint64{100, 102}) {
+		t.Errorf("Ancestors returned %v", ids)
+	}
+
+	ids = nil
+	for _, c := range tree.Leaves() {
+		ids = append(ids, c.CategoryID)
+	}
+	if !reflect.DeepEqual(ids, []uint64{101, 103, 200}) {
+		t.Errorf("Leaves returned %v", ids)
+	}
+}
+
+func Test_CategoryTreeSave(t *testing.T) {
+	tree := NewCategoryTree(testCategories)
+	tree.Region, tree.Language, tree.FetchedAt = "SG", "en", time.Unix(1655714431, 0).UTC()
+
+	path := filepath.Join(t.TempDir(), "categories.json")
+	if err := tree.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadCategoryTree(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Region != "SG" || !loaded.FetchedAt.Equal(tree.FetchedAt) || loaded.Path(103) != tree.Path(103) {
+		t.Errorf("LoadCategoryTree returned %+v", loaded)
+	}
+}
+
+func Test_CategoryCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_category", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("category_list.json")))
+
+	now := time.Unix(1655714431, 0)
+	dir := t.TempDir()
+	cache := NewCategoryCache(client)
+	cache.Dir = dir
+	cache.TTL = time.Hour
+	cache.Now = func() time.Time { return now }
+
+	calls := func() int {
+		return httpmock.GetCallCountInfo()[fmt.Sprintf("GET %s/api/v2/product/get_category", app.APIURL)]
+	}
+	for i := 0; i < 2; i++ {
+		tree, err := cache.Tree(context.Background(), shopID, "SG", "en", accessToken)
+		if err != nil {
+			t.Fatalf("CategoryCache.Tree error: %s", err)
+		}
+		if _, ok := tree.Get(123); !ok {
+			t.Errorf("category 123 not in tree")
+		}
+	}
+	if calls() != 1 {
+		t.Errorf("get_category called %d times, expected 1", calls())
+	}
+
+	// another process reads the tree saved to dir
+	other := NewCategoryCache(client)
+	other.Dir = dir
+	other.TTL = time.Hour
+	other.Now = cache.Now
+	if _, err := other.Tree(context.Background(), shopID, "SG", "en", accessToken); err != nil || calls() != 1 {
+		t.Errorf("tree not loaded from disk, %d calls, %v", calls(), err)
+	}
+
+	if _, err := cache.Tree(context.Background(), shopID, "SG", "zh-Hant", accessToken); err != nil || calls() != 2 {
+		t.Errorf("tree of another language not fetched, %d calls, %v", calls(), err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := cache.Tree(context.Background(), shopID, "SG", "en", accessToken); err != nil || calls() != 3 {
+		t.Errorf("expired tree not fetched, %d calls, %v", calls(), err)
+	}
+
+	if err := cache.Invalidate("SG", "en"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Tree(context.Background(), shopID, "SG", "en", accessToken); err != nil || calls() != 4 {
+		t.Errorf("invalidated tree not fetched, %d calls, %v", calls(), err)
+	}
+}
+
+func Test_CategoryCacheSharedFetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_category", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			<-release
+			return httpmock.NewBytesResponse(200, loadFixture("category_list.json")), nil
+		})
+
+	// a file in place of the directory fails every save
+	dir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(dir, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var saveErrs int32
+	cache := NewCategoryCache(client)
+	cache.Dir = dir
+	cache.OnSaveError = func(region, lang string, err error) { atomic.AddInt32(&saveErrs, 1) }
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tree, err := cache.Tree(context.Background(), shopID, "SG", "en", accessToken)
+			if err == nil && tree == nil {
+				err = errors.New("no tree")
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("CategoryCache.Tree error: %s", err)
+		}
+	}
+	if n := httpmock.GetTotalCallCount(); n != 1 {
+		t.Errorf("get_category called %d times, expected 1", n)
+	}
+	if n := atomic.LoadInt32(&saveErrs); n != 1 {
+		t.Errorf("OnSaveError called %d times, expected 1", n)
+	}
+}
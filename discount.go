@@ -1,12 +1,20 @@
 package goshopee
 
+import "context"
+
 type DiscountService interface {
 	GetDiscountList(uint64, GetDiscountListRequest, string) (*GetDiscountListResponse, error)
+	GetDiscountListWithContext(context.Context, uint64, GetDiscountListRequest, string) (*GetDiscountListResponse, error)
 	GetDiscount(uint64, GetDiscountRequest, string) (*GetDiscountResponse, error)
+	GetDiscountWithContext(context.Context, uint64, GetDiscountRequest, string) (*GetDiscountResponse, error)
 	AddDiscount(uint64, AddDiscountRequest, string) (*AddDiscountResponse, error)
+	AddDiscountWithContext(context.Context, uint64, AddDiscountRequest, string) (*AddDiscountResponse, error)
 	AddDiscountItem(uint64, AddDiscountItemRequest, string) (*AddDiscountItemResponse, error)
+	AddDiscountItemWithContext(context.Context, uint64, AddDiscountItemRequest, string) (*AddDiscountItemResponse, error)
 	DeleteDiscountItem(uint64, uint64, uint64, uint64, string) (*DeleteDiscountItemResponse, error)
+	DeleteDiscountItemWithContext(context.Context, uint64, uint64, uint64, uint64, string) (*DeleteDiscountItemResponse, error)
 	UpdateDiscountItem(uint64, UpdateDiscountItemRequest, string) (*UpdateDiscountItemResponse, error)
+	UpdateDiscountItemWithContext(context.Context, uint64, UpdateDiscountItemRequest, string) (*UpdateDiscountItemResponse, error)
 }
 
 type DiscountServiceOp struct {
@@ -17,9 +25,9 @@ type DiscountServiceOp struct {
 // https://open.shopee.cn/documents/v2/v2.discount.get_discount?module=99&type=1
 
 type GetDiscountRequest struct {
-	DiscountID uint64 `json:"discount_id"`
-	PageNo     int    `json:"page_no"`
-	PageSize   int    `json:"page_size"`
+	DiscountID uint64 `url:"discount_id"`
+	PageNo     int    `url:"page_no"`
+	PageSize   int    `url:"page_size"`
 }
 
 type GetDiscountResponse struct {
@@ -63,10 +71,14 @@ type GetDiscountResponseDataItemModel struct {
 }
 
 func (s *DiscountServiceOp) GetDiscount(sid uint64, opt GetDiscountRequest, tok string) (*GetDiscountResponse, error) {
+	return s.GetDiscountWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *DiscountServiceOp) GetDiscountWithContext(ctx context.Context, sid uint64, opt GetDiscountRequest, tok string) (*GetDiscountResponse, error) {
 	path := "/discount/get_discount"
 
 	resp := new(GetDiscountResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -81,11 +93,11 @@ const (
 )
 
 type GetDiscountListRequest struct {
-	DiscountStatus string `json:"discount_status"`
-	PageNo         int    `json:"page_no"`
-	PageSize       int    `json:"page_size"`
-	UpdateTimeFrom int64  `json:"update_time_from"`
-	UpdateTimeTo   int64  `json:"update_time_to"`
+	DiscountStatus string `url:"discount_status"`
+	PageNo         int    `url:"page_no"`
+	PageSize       int    `url:"page_size"`
+	UpdateTimeFrom int64  `url:"update_time_from,omitempty"`
+	UpdateTimeTo   int64  `url:"update_time_to,omitempty"`
 }
 
 type GetDiscountListResponse struct {
@@ -115,10 +127,14 @@ type GetDiscountListResponseDataDiscount struct {
 }
 
 func (s *DiscountServiceOp) GetDiscountList(sid uint64, opt GetDiscountListRequest, tok string) (*GetDiscountListResponse, error) {
+	return s.GetDiscountListWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *DiscountServiceOp) GetDiscountListWithContext(ctx context.Context, sid uint64, opt GetDiscountListRequest, tok string) (*GetDiscountListResponse, error) {
 	path := "/discount/get_discount_list"
 
 	resp := new(GetDiscountListResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -139,13 +155,17 @@ type AddDiscountResponseData struct {
 }
 
 func (s *DiscountServiceOp) AddDiscount(sid uint64, data AddDiscountRequest, tok string) (*AddDiscountResponse, error) {
+	return s.AddDiscountWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *DiscountServiceOp) AddDiscountWithContext(ctx context.Context, sid uint64, data AddDiscountRequest, tok string) (*AddDiscountResponse, error) {
 	path := "/discount/add_discount"
 	resp := new(AddDiscountResponse)
 	req, err := StructToMap(data)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -188,13 +208,17 @@ type AddDiscountItemResponseDataError struct {
 }
 
 func (s *DiscountServiceOp) AddDiscountItem(sid uint64, data AddDiscountItemRequest, tok string) (*AddDiscountItemResponse, error) {
+	return s.AddDiscountItemWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *DiscountServiceOp) AddDiscountItemWithContext(ctx context.Context, sid uint64, data AddDiscountItemRequest, tok string) (*AddDiscountItemResponse, error) {
 	path := "/discount/add_discount_item"
 	resp := new(AddDiscountItemResponse)
 	req, err := StructToMap(data)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -217,6 +241,10 @@ type DeleteDiscountItemResponseDataError struct {
 }
 
 func (s *DiscountServiceOp) DeleteDiscountItem(sid, discountID, itemID, modelID uint64, tok string) (*DeleteDiscountItemResponse, error) {
+	return s.DeleteDiscountItemWithContext(context.Background(), sid, discountID, itemID, modelID, tok)
+}
+
+func (s *DiscountServiceOp) DeleteDiscountItemWithContext(ctx context.Context, sid, discountID, itemID, modelID uint64, tok string) (*DeleteDiscountItemResponse, error) {
 	path := "/discount/delete_discount_item"
 	wrappedData := map[string]interface{}{
 		"discount_id": discountID,
@@ -224,7 +252,7 @@ func (s *DiscountServiceOp) DeleteDiscountItem(sid, discountID, itemID, modelID
 		"model_id":    modelID,
 	}
 	resp := new(DeleteDiscountItemResponse)
-	err := s.client.WithShop(sid, tok).Post(path, wrappedData, resp)
+	err := s.client.WithShop(sid, tok).PostWithContext(ctx, path, wrappedData, resp)
 	return resp, err
 }
 
@@ -269,12 +297,16 @@ type UpdateDiscountItemResponseDataError struct {
 }
 
 func (s *DiscountServiceOp) UpdateDiscountItem(sid uint64, data UpdateDiscountItemRequest, tok string) (*UpdateDiscountItemResponse, error) {
+	return s.UpdateDiscountItemWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *DiscountServiceOp) UpdateDiscountItemWithContext(ctx context.Context, sid uint64, data UpdateDiscountItemRequest, tok string) (*UpdateDiscountItemResponse, error) {
 	path := "/discount/update_discount_item"
 	resp := new(UpdateDiscountItemResponse)
 	req, err := StructToMap(data)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
@@ -0,0 +1,120 @@
+package goshopee
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Classes of api errors. ResponseError and RateLimitError match them with
+// errors.Is:
+//
+//	if errors.Is(err, goshopee.ErrAuth) {
+//		// ask the seller to authorize the shop again
+//	}
+var (
+	// ErrAuth is an invalid or expired access token, a bad signature or a
+	// missing permission
+	ErrAuth = errors.New("goshopee: authentication error")
+	// ErrRateLimited is a request over Shopee's rate limit
+	ErrRateLimited = errors.New("goshopee: rate limited")
+	// ErrServer is a temporary failure on Shopee's side, worth retrying
+	ErrServer = errors.New("goshopee: server error")
+	// ErrValidation is a request Shopee rejected for its parameters
+	ErrValidation = errors.New("goshopee: validation error")
+	// ErrNotFound is a request for something that does not exist
+	ErrNotFound = errors.New("goshopee: not found")
+)
+
+var authErrors = map[string]bool{
+	"invalid_access_token":  true,
+	"invalid_acceess_token": true,
+	"error_auth":            true,
+	"error_permission":      true,
+	"error_sign":            true,
+	"invalid_partner_id":    true,
+	"error_invalid_token":   true,
+	"error_refresh_token":   true,
+}
+
+// rateLimitErrors are the shopee error codes telling a request was over the
+// limit, returned with or without a 429 status
+var rateLimitErrors = map[string]bool{
+	"error_rate_limit":       true,
+	"error_too_many_request": true,
+}
+
+// validationPrefixes start the shopee error codes of rejected parameters,
+// misspelled ones included, e.g. error_incalid_category
+var validationPrefixes = []string{
+	"error_param", "error_invalid", "error_incalid", "invalid_",
+	"error_category", "error_attribute", "error_brand", "error_format",
+	"error_missing", "error_exceed",
+}
+
+var serverErrors = map[string]bool{
+	"error_server":  true,
+	"error_busy":    true,
+	"error_inner":   true,
+	"error_network": true,
+	"error_system":  true,
+}
+
+// errorClass returns the class of a shopee error code returned with http
+// status, or nil if it fits none.
+func errorClass(code string, status int) error {
+	code = strings.TrimSuffix(code, ".")
+	switch {
+	case status == http.StatusTooManyRequests || rateLimitErrors[code]:
+		return ErrRateLimited
+	case authErrors[code]:
+		return ErrAuth
+	case serverErrors[code]:
+		return ErrServer
+	case strings.HasSuffix(code, "_not_found") || strings.HasSuffix(code, "_not_exist"):
+		return ErrNotFound
+	case hasAnyPrefix(code, validationPrefixes):
+		return ErrValidation
+	}
+
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuth
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrServer
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusBadRequest:
+		return ErrValidation
+	}
+	return nil
+}
+
+// Is reports whether the error belongs to the class target, one of ErrAuth,
+// ErrRateLimited, ErrServer, ErrValidation and ErrNotFound.
+func (e ResponseError) Is(target error) bool {
+	class := errorClass(e.Code, e.Status)
+	return class != nil && class == target
+}
+
+// Is reports whether the error belongs to the class target by its http
+// status, e.g. ErrServer for the html page of a 503 from a gateway.
+func (e ResponseDecodingError) Is(target error) bool {
+	class := errorClass("", e.Status)
+	return class != nil && class == target
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryable reports whether a request failing with err may succeed when
+// sent again.
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer)
+}
@@ -0,0 +1,134 @@
+package goshopee
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_ResponseErrorIs(t *testing.T) {
+	cases := []struct {
+		err      ResponseError
+		expected error
+	}{
+		{ResponseError{Status: 403, Code: "invalid_access_token"}, ErrAuth},
+		{ResponseError{Status: 200, Code: "error_permission"}, ErrAuth},
+		{ResponseError{Status: 401}, ErrAuth},
+		{ResponseError{Status: 200, Code: "error_rate_limit"}, ErrRateLimited},
+		{ResponseError{Status: 429}, ErrRateLimited},
+		{ResponseError{Status: 200, Code: "error_server"}, ErrServer},
+		{ResponseError{Status: 200, Code: "error_busy"}, ErrServer},
+		{ResponseError{Status: 503}, ErrServer},
+		{ResponseError{Status: 200, Code: "error_param"}, ErrValidation},
+		{ResponseError{Status: 200, Code: "error_incalid_category."}, ErrValidation},
+		{ResponseError{Status: 200, Code: "error_item_not_found"}, ErrNotFound},
+		{ResponseError{Status: 404}, ErrNotFound},
+		{ResponseError{Status: 200, Code: "error_category_is_block."}, ErrValidation},
+		{ResponseError{Status: 200, Code: "error_unknown"}, nil},
+	}
+
+	classes := []error{ErrAuth, ErrRateLimited, ErrServer, ErrValidation, ErrNotFound}
+	for _, c := range cases {
+		for _, class := range classes {
+			if errors.Is(c.err, class) != (class == c.expected) {
+				t.Errorf("errors.Is(%s/%d, %s) returned %v", c.err.Code, c.err.Status, class, !(class == c.expected))
+			}
+		}
+	}
+}
+
+func Test_RetryBodyLevelServerError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return httpmock.NewStringResponse(200, `{"error":"error_busy","message":"system busy","request_id":"abc"}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_profile_resp.json")), nil
+		})
+
+	if _, err := client.Shop.GetProfile(shopID, accessToken); err != nil {
+		t.Errorf("Shop.GetProfile error: %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("Shop.GetProfile sent %d requests, expected 2", calls)
+	}
+}
+
+func Test_RetryGatewayErrorPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				resp := httpmock.NewStringResponse(503, "<html><body><h1>503 Service Temporarily Unavailable</h1></body></html>")
+				resp.Header.Set("Content-Type", "text/html")
+				return resp, nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_profile_resp.json")), nil
+		})
+
+	if _, err := client.Shop.GetProfile(shopID, accessToken); err != nil {
+		t.Errorf("Shop.GetProfile error: %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("Shop.GetProfile sent %d requests, expected 2", calls)
+	}
+
+	if err := (ResponseDecodingError{Status: 502}); !errors.Is(err, ErrServer) {
+		t.Errorf("errors.Is(%#v, ErrServer) returned false", err)
+	}
+}
+
+func Test_ErrorKeepsRequestID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(200, `{"error":"error_param","message":"bad shop_id","request_id":"2069449bd255af166cb52b0e15189d6d"}`), nil
+		})
+
+	_, err := client.Shop.GetProfile(shopID, accessToken)
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Shop.GetProfile returned %v, expected a validation error", err)
+	}
+	if calls != 1 {
+		t.Errorf("validation error retried, %d requests sent", calls)
+	}
+
+	var respErr ResponseError
+	if !errors.As(err, &respErr) || respErr.RequestID != "2069449bd255af166cb52b0e15189d6d" {
+		t.Errorf("ResponseError.RequestID returned %q", respErr.RequestID)
+	}
+	if !strings.Contains(err.Error(), "request_id=2069449bd255af166cb52b0e15189d6d") {
+		t.Errorf("Error() returned %q, expected the request id", err.Error())
+	}
+}
+
+func Test_BodyLevelRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		httpmock.NewStringResponder(200, `{"error":"error_rate_limit","message":"too many requests","request_id":"abc"}`))
+
+	_, err := client.Shop.GetProfile(shopID, accessToken)
+	var rateLimitErr RateLimitError
+	if !errors.As(err, &rateLimitErr) || !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Shop.GetProfile returned %#v, expected a RateLimitError", err)
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/passwind/go-shopee-v2
 
-go 1.15
+go 1.21
 
 require (
 	github.com/caarlos0/env v3.5.0+incompatible
@@ -8,5 +8,13 @@ require (
 	github.com/jarcoal/httpmock v1.0.8
 	github.com/joho/godotenv v1.3.0
 	github.com/prometheus/common v0.25.0
+)
+
+require (
+	github.com/alecthomas/template v0.0.0-20190718012654-fb15b899a751 // indirect
+	github.com/alecthomas/units v0.0.0-20190924025748-f65c72e2690d // indirect
+	github.com/konsorten/go-windows-terminal-sequences v1.0.3 // indirect
+	github.com/sirupsen/logrus v1.6.0 // indirect
 	golang.org/x/sys v0.0.0-20210423082822-04245dca01da // indirect
+	gopkg.in/alecthomas/kingpin.v2 v2.2.6 // indirect
 )
@@ -4,10 +4,9 @@ package goshopee
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -41,6 +40,8 @@ type App struct {
 	Client      *Client
 }
 
+// RateLimitInfo is the state of the rate limiter bucket of a call, see
+// CallInfo
 type RateLimitInfo struct {
 	RequestCount      int
 	BucketSize        int
@@ -54,15 +55,39 @@ type Client struct {
 
 	app App
 
+	// signs the requests, see WithSigner option
+	signer *Signer
+
 	// Base URL for API requests.
 	baseURL *url.URL
 
-	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
+	// decides which failed requests are sent again, nil for no retries, see
+	// WithRetry and WithRetryPolicy options
+	retry RetryPolicy
 
+	// RateLimits is the state of the rate limiter bucket after the last
+	// request made with a scoped copy, see WithShop. The shared client is left
+	// untouched.
+	//
+	// Deprecated: scoped copies may be shared by goroutines, use
+	// CallInfo.RateLimit to get the state of one call.
 	RateLimits RateLimitInfo
 
+	// hides secrets and buyer data from the logs, see WithRedactor option
+	redactor *Redactor
+
+	// wrap the sending of every request, see WithInterceptors option
+	interceptors []Interceptor
+
+	// optional client side throttling, see WithRateLimiter option
+	limiter *RateLimiter
+
+	// optional token lookup and refresh, see WithTokenStore option
+	tokens *tokenManager
+
+	// Credentials the requests are signed with. They are only set on the
+	// scoped copies returned by WithShop, WithMerchant and WithToken, never on
+	// the shared client, so one Client can serve many shops concurrently.
 	ShopID      uint64
 	MerchantID  uint64
 	AccessToken string
@@ -76,7 +101,10 @@ type Client struct {
 	Shop      ShopService
 	Discount  DiscountService
 	Order     OrderService
+	Returns   ReturnsService
+	Payment   PaymentService
 	Merchant  MerchantService
+	Push      PushService
 }
 
 // NewClient returns a new Shopify API client with an already authenticated shopname and
@@ -90,10 +118,12 @@ func NewClient(app App, opts ...Option) *Client {
 	}
 
 	c := &Client{
-		Client:  &http.Client{},
-		log:     &LeveledLogger{},
-		app:     app,
-		baseURL: baseURL,
+		Client:   &http.Client{},
+		log:      &LeveledLogger{},
+		redactor: DefaultRedactor(),
+		app:      app,
+		signer:   NewSigner(app.PartnerID, app.PartnerKey),
+		baseURL:  baseURL,
 	}
 
 	c.Util = &UtilServiceOp{client: c}
@@ -104,7 +134,10 @@ func NewClient(app App, opts ...Option) *Client {
 	c.Shop = &ShopServiceOp{client: c}
 	c.Discount = &DiscountServiceOp{client: c}
 	c.Order = &OrderServiceOp{client: c}
+	c.Returns = &ReturnsServiceOp{client: c}
+	c.Payment = &PaymentServiceOp{client: c}
 	c.Merchant = &MerchantServiceOp{client: c}
+	c.Push = &PushServiceOp{client: c}
 
 	// apply any options
 	for _, opt := range opts {
@@ -117,9 +150,11 @@ func NewClient(app App, opts ...Option) *Client {
 // A general response error that follows a similar layout to Shopify's response
 // errors, i.e. either a single message or a list of messages.
 type ResponseError struct {
-	Status  int
-	Message string
-	Errors  []string
+	Status    int
+	Code      string // shopee error code, e.g. error_param
+	Message   string
+	Errors    []string
+	RequestID string // quote it when asking Shopee support about the error
 }
 
 // GetStatus returns http  response status
@@ -138,18 +173,19 @@ func (e ResponseError) GetErrors() []string {
 }
 
 func (e ResponseError) Error() string {
-	if e.Message != "" {
-		return e.Message
+	s := e.Message
+	if s == "" {
+		sort.Strings(e.Errors)
+		s = strings.Join(e.Errors, ", ")
 	}
-
-	sort.Strings(e.Errors)
-	s := strings.Join(e.Errors, ", ")
-
-	if s != "" {
-		return s
+	if s == "" {
+		s = "Unknown Error"
 	}
 
-	return "Unknown Error"
+	if e.RequestID != "" {
+		s += " request_id=" + e.RequestID
+	}
+	return s
 }
 
 // ResponseDecodingError occurs when the response body from Shopify could
@@ -176,6 +212,11 @@ type RateLimitError struct {
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body.
 func (c *Client) NewRequest(method, relPath string, body, options, headers interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, relPath, body, options, headers)
+}
+
+// NewRequestWithContext is like NewRequest but binds the request to ctx.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, relPath string, body, options, headers interface{}) (*http.Request, error) {
 	rel, err := url.Parse(relPath)
 	if err != nil {
 		return nil, err
@@ -208,7 +249,7 @@ func (c *Client) NewRequest(method, relPath string, body, options, headers inter
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
 		return nil, err
 	}
@@ -222,29 +263,48 @@ func (c *Client) NewRequest(method, relPath string, body, options, headers inter
 	return req, nil
 }
 
+// WithShop returns a copy of the client scoped to the shop sid. Requests made
+// through the copy are signed as shop APIs with tok. The receiver is not
+// modified, so it is safe to call from many goroutines on a shared Client.
 func (c *Client) WithShop(sid uint64, tok string) *Client {
-	c.ShopID = sid
-	c.AccessToken = tok
-	return c
+	sc := c.clone()
+	sc.ShopID = sid
+	sc.MerchantID = 0
+	sc.AccessToken = tok
+	return sc
 }
 
+// WithMerchant returns a copy of the client scoped to the merchant mid.
+// Requests made through the copy are signed as merchant APIs with tok.
 func (c *Client) WithMerchant(mid uint64, tok string) *Client {
-	c.MerchantID = mid
-	c.AccessToken = tok
-	return c
+	sc := c.clone()
+	sc.ShopID = 0
+	sc.MerchantID = mid
+	sc.AccessToken = tok
+	return sc
 }
 
+// WithToken returns a copy of the client that keeps the current scope but
+// uses tok as access token.
 func (c *Client) WithToken(tok string) *Client {
-	c.AccessToken = tok
-	return c
+	sc := c.clone()
+	sc.AccessToken = tok
+	return sc
+}
+
+// clone makes a shallow copy of the client. Everything shared between the
+// copies (http client, logger, services) is held by pointer.
+func (c *Client) clone() *Client {
+	sc := *c
+	return &sc
 }
 
 // https://open.shopee.com/documents?module=87&type=2&id=58&version=2
 func (c *Client) makeSignature(req *http.Request) (string, int64) {
-	ts := time.Now().Unix()
 	path := req.URL.Path
 
-	var baseStr string
+	var sign string
+	var ts int64
 
 	u := req.URL
 
@@ -252,30 +312,35 @@ func (c *Client) makeSignature(req *http.Request) (string, int64) {
 	query.Add("partner_id", fmt.Sprintf("%v", c.app.PartnerID))
 
 	if c.ShopID != 0 {
-		// Shop APIs: partner_id, api path, timestamp, access_token, shop_id
-		baseStr = fmt.Sprintf("%d%s%d%s%d", c.app.PartnerID, path, ts, c.AccessToken, c.ShopID)
+		sign, ts = c.signer.SignShop(path, c.AccessToken, c.ShopID)
 		query.Add("shop_id", fmt.Sprintf("%v", c.ShopID))
 		query.Add("access_token", c.AccessToken)
 	} else if c.MerchantID != 0 {
-		// Merchant APIs: partner_id, api path, timestamp, access_token, merchant_id
-		baseStr = fmt.Sprintf("%d%s%d%s%d", c.app.PartnerID, path, ts, c.AccessToken, c.MerchantID)
+		sign, ts = c.signer.SignMerchant(path, c.AccessToken, c.MerchantID)
 		query.Add("merchant_id", fmt.Sprintf("%v", c.MerchantID))
 		query.Add("access_token", c.AccessToken)
 	} else {
-		// Public APIs: partner_id, api path, timestamp
-		baseStr = fmt.Sprintf("%d%s%d", c.app.PartnerID, path, ts)
+		sign, ts = c.signer.SignPublic(path)
 	}
-	h := hmac.New(sha256.New, []byte(c.app.PartnerKey))
-	h.Write([]byte(baseStr))
-	result := hex.EncodeToString(h.Sum(nil))
 
 	query.Add("timestamp", fmt.Sprintf("%v", ts))
-	query.Add("sign", result)
+	query.Add("sign", sign)
 
 	u.RawQuery = query.Encode()
 	req.URL = u
 
-	return result, ts
+	return sign, ts
+}
+
+// resign signs req again with a new timestamp. Shopee rejects timestamps
+// older than 5 minutes, which a long backoff may exceed.
+func (c *Client) resign(req *http.Request) {
+	query := req.URL.Query()
+	for _, k := range []string{"partner_id", "shop_id", "merchant_id", "access_token", "timestamp", "sign"} {
+		query.Del(k)
+	}
+	req.URL.RawQuery = query.Encode()
+	c.makeSignature(req)
 }
 
 // doGetHeaders executes a request, decoding the response into `v` and also returns any response headers.
@@ -283,20 +348,46 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}, skipBody bool) (
 	var resp *http.Response
 	var err error
 
-	retries := c.retries
-	c.attempts = 0
+	info := callInfoFrom(req.Context())
 	c.logRequest(req, skipBody)
 
-	for {
-		c.attempts++
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			// the timestamp of the first attempt may be too old by now
+			c.resign(req)
+		}
+		if attempt > 1 && req.GetBody != nil {
+			// the previous attempt consumed the body
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.limiter != nil {
+			if err := c.limiter.Wait(req.Context(), c.rateLimitKey(req)); err != nil {
+				return nil, err
+			}
+		}
 
-		resp, err = c.Client.Do(req)
+		res := c.roundTrip(&Attempt{
+			Request:    req,
+			Path:       apiPath(req.URL.Path),
+			ShopID:     c.ShopID,
+			MerchantID: c.MerchantID,
+			Number:     attempt,
+		})
+		if info != nil {
+			info.Attempts++
+		}
+		resp = res.Response
 		c.logResponse(resp)
-		if err != nil {
-			return nil, err //http client errors, not api responses
+		c.logAttempt(req, skipBody, attempt, res)
+		if resp == nil {
+			return nil, res.Err //http client errors, not api responses
 		}
 
-		respErr := CheckResponseError(resp)
+		respErr := res.Err
+		c.observeRateLimit(req, respErr, info)
 		if respErr == nil {
 			break // no errors, break out of the retry loop
 		}
@@ -304,37 +395,21 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}, skipBody bool) (
 		// retry scenario, close resp and any continue will retry
 		resp.Body.Close()
 
-		if retries <= 1 {
+		if c.retry == nil {
 			return nil, respErr
 		}
-
-		if rateLimitErr, isRetryErr := respErr.(RateLimitError); isRetryErr {
-			// back off and retry
-
-			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
-			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
-			retries--
-			continue
-		}
-
-		var doRetry bool
-		switch resp.StatusCode {
-		case http.StatusServiceUnavailable:
-			c.log.Debugf("service unavailable, retrying")
-			doRetry = true
-			retries--
+		wait, retry := c.retry.Retry(req, attempt, respErr)
+		if !retry {
+			return nil, respErr
 		}
 
-		if doRetry {
-			continue
+		// back off and retry
+		c.log.Debugf("attempt %d: %s, retrying in %s", attempt, respErr, wait)
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
 		}
-
-		// no retry attempts, just return the err
-		return nil, respErr
 	}
 
-	c.logResponse(resp)
 	defer resp.Body.Close()
 
 	if v != nil {
@@ -348,13 +423,65 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}, skipBody bool) (
 	return resp.Header, nil
 }
 
+func (c *Client) rateLimitKey(req *http.Request) RateLimitKey {
+	return RateLimitKey{
+		PartnerID:  c.app.PartnerID,
+		ShopID:     c.ShopID,
+		MerchantID: c.MerchantID,
+		Path:       req.URL.Path,
+	}
+}
+
+// observeRateLimit feeds the outcome of a request back to the limiter and
+// fills the RateLimit of info and RateLimits.
+func (c *Client) observeRateLimit(req *http.Request, respErr error, info *CallInfo) {
+	key := c.rateLimitKey(req)
+	rateLimitErr, limited := respErr.(RateLimitError)
+	retryAfter := time.Duration(rateLimitErr.RetryAfter) * time.Second
+
+	if c.limiter != nil && limited {
+		wait := retryAfter
+		if wait <= 0 {
+			wait = defaultRateLimitBackoff
+		}
+		c.limiter.rateLimited(key, wait)
+	}
+	var state RateLimitInfo
+	if c.limiter != nil {
+		state = c.limiter.info(key)
+	}
+	if limited && retryAfter > 0 {
+		// what Shopee asked for wins over the estimate of the limiter
+		state.RetryAfterSeconds = retryAfter.Seconds()
+	}
+	if info != nil {
+		info.RateLimit = state
+	}
+	if c.ShopID != 0 || c.MerchantID != 0 {
+		c.RateLimits = state
+	}
+}
+
+// sleepContext pauses for d or until ctx is done, whichever happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 // skipBody: if upload image, skip log its binary
 func (c *Client) logRequest(req *http.Request, skipBody bool) {
-	if req == nil {
+	if _, ok := c.log.(RequestLogger); ok || req == nil {
 		return
 	}
 	if req.URL != nil {
-		c.log.Debugf("%s: %s", req.Method, req.URL.String())
+		c.log.Debugf("%s: %s", req.Method, c.redactor.RedactURL(req.URL))
 	}
 	if !skipBody {
 		c.logBody(&req.Body, "SENT: %s")
@@ -362,7 +489,7 @@ func (c *Client) logRequest(req *http.Request, skipBody bool) {
 }
 
 func (c *Client) logResponse(res *http.Response) {
-	if res == nil {
+	if _, ok := c.log.(RequestLogger); ok || res == nil {
 		return
 	}
 	c.log.Debugf("RECV %d: %s", res.StatusCode, res.Status)
@@ -370,19 +497,62 @@ func (c *Client) logResponse(res *http.Response) {
 }
 
 func (c *Client) logBody(body *io.ReadCloser, format string) {
-	if body == nil || *body == nil {
+	b := readBody(body)
+	if len(b) > 0 {
+		c.log.Debugf(format, string(c.redactor.RedactBody(b)))
+	}
+}
+
+// logAttempt hands one attempt of req to the logger if it is a RequestLogger
+func (c *Client) logAttempt(req *http.Request, skipBody bool, attempt int, res *AttemptResult) {
+	rl, ok := c.log.(RequestLogger)
+	if !ok {
 		return
 	}
-	b, _ := ioutil.ReadAll(*body)
-	if len(b) > 0 {
-		c.log.Debugf(format, string(b))
+
+	e := RequestLog{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		ShopID:     c.ShopID,
+		MerchantID: c.MerchantID,
+		Duration:   res.Duration,
+		RequestID:  res.RequestID,
+		Attempt:    attempt,
+		Err:        res.Err,
+	}
+	if res.Response != nil {
+		e.Status = res.Response.StatusCode
+	}
+
+	bodies := true
+	if bl, ok := rl.(RequestBodyLogger); ok {
+		bodies = bl.LogsBodies(req.Context())
+	}
+	if bodies && !skipBody && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			e.RequestBody = c.redactor.RedactBody(readBody(&body))
+		}
+	}
+	if bodies && res.Response != nil {
+		e.ResponseBody = c.redactor.RedactBody(readBody(&res.Response.Body))
+	}
+
+	rl.LogRequest(req.Context(), e)
+}
+
+// readBody reads *body whole and puts back a reader of the same bytes
+func readBody(body *io.ReadCloser) []byte {
+	if body == nil || *body == nil {
+		return nil
 	}
+	b, _ := ioutil.ReadAll(*body)
+	(*body).Close()
 	*body = ioutil.NopCloser(bytes.NewBuffer(b))
+	return b
 }
 
 func wrapSpecificError(r *http.Response, err ResponseError) error {
-	// TODO: check rate-limit error for shopee
-	if err.Status == http.StatusTooManyRequests {
+	if errors.Is(err, ErrRateLimited) {
 		f, _ := strconv.ParseFloat(r.Header.Get("Retry-After"), 64)
 		return RateLimitError{
 			ResponseError: err,
@@ -408,8 +578,9 @@ func wrapSpecificError(r *http.Response, err ResponseError) error {
 // {"error":"error_category_is_block.","message":"Category is restricted","request_id":"97994a47af37a22da79cb910bfd9841a"}
 func CheckResponseError(r *http.Response) error {
 	shopeeError := struct {
-		Error   string `json:"error"`
-		Message string `json:"message"`
+		Error     string `json:"error"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
 	}{}
 
 	bodyBytes, err := ioutil.ReadAll(r.Body)
@@ -438,8 +609,10 @@ func CheckResponseError(r *http.Response) error {
 	}
 
 	responseError := ResponseError{
-		Status:  r.StatusCode,
-		Message: fmt.Sprintf("shopee-%s [%s]", shopeeError.Error, shopeeError.Message),
+		Status:    r.StatusCode,
+		Code:      shopeeError.Error,
+		Message:   fmt.Sprintf("shopee-%s [%s]", shopeeError.Error, shopeeError.Message),
+		RequestID: shopeeError.RequestID,
 	}
 
 	return wrapSpecificError(r, responseError)
@@ -455,22 +628,50 @@ func CheckResponseError(r *http.Response) error {
 // parameters like created_at_min
 // Any data returned from Shopify will be marshalled into resource argument.
 func (c *Client) CreateAndDo(method, relPath string, data, options, headers, resource interface{}) error {
-	defer func() {
-		// clear for next call
-		c.ShopID = 0
-		c.MerchantID = 0
-		c.AccessToken = ""
-	}()
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, data, options, headers, resource)
+}
+
+// CreateAndDoWithContext is like CreateAndDo but binds the request to ctx.
+// Cancelling ctx aborts the call, including any retry backoff in progress.
+//
+// If the client has a token store and the request is scoped to a shop or
+// merchant without an access token, the token is taken from the store. A
+// request rejected for an invalid token is retried once with a refreshed one.
+func (c *Client) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, options, headers, resource interface{}) error {
+	return c.withStoredToken(ctx, func(c *Client) error {
+		_, err := c.createAndDoGetHeaders(ctx, method, relPath, data, options, headers, resource)
+		return err
+	})
+}
+
+// withStoredToken calls do with c, or with a copy of c carrying the access
+// token of the store when c has none. do is called again with a refreshed
+// token if the first one is rejected.
+func (c *Client) withStoredToken(ctx context.Context, do func(*Client) error) error {
+	if c.tokens == nil || c.AccessToken != "" || (c.ShopID == 0 && c.MerchantID == 0) {
+		return do(c)
+	}
 
-	_, err := c.createAndDoGetHeaders(method, relPath, data, options, headers, resource)
+	key := TokenKey{ShopID: c.ShopID, MerchantID: c.MerchantID}
+	tok, err := c.tokens.token(ctx, key)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	err = do(c.WithToken(tok.AccessToken))
+	if !isInvalidTokenError(err) {
+		return err
+	}
+
+	c.log.Debugf("access token of %s rejected, refreshing", key)
+	if tok, err = c.tokens.refresh(ctx, key, tok.AccessToken); err != nil {
+		return err
+	}
+	return do(c.WithToken(tok.AccessToken))
 }
 
 // createAndDoGetHeaders creates an executes a request while returning the response headers.
-func (c *Client) createAndDoGetHeaders(method, relPath string, data, options, headers, resource interface{}) (http.Header, error) {
+func (c *Client) createAndDoGetHeaders(ctx context.Context, method, relPath string, data, options, headers, resource interface{}) (http.Header, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
@@ -484,7 +685,7 @@ func (c *Client) createAndDoGetHeaders(method, relPath string, data, options, he
 		data = params
 	}
 
-	req, err := c.NewRequest(method, relPath, data, options, headers)
+	req, err := c.NewRequestWithContext(ctx, method, relPath, data, options, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -495,42 +696,83 @@ func (c *Client) createAndDoGetHeaders(method, relPath string, data, options, he
 // Get performs a GET request for the given path and saves the result in the
 // given resource.
 func (c *Client) Get(path string, resource, options interface{}) error {
-	return c.CreateAndDo("GET", path, nil, options, nil, resource)
+	return c.GetWithContext(context.Background(), path, resource, options)
+}
+
+// GetWithContext is like Get but binds the request to ctx.
+func (c *Client) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "GET", path, nil, options, nil, resource)
 }
 
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
-	return c.CreateAndDo("POST", path, data, nil, nil, resource)
+	return c.PostWithContext(context.Background(), path, data, resource)
+}
+
+// PostWithContext is like Post but binds the request to ctx.
+func (c *Client) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "POST", path, data, nil, nil, resource)
 }
 
 // Put performs a PUT request for the given path and saves the result in the
 // given resource.
 func (c *Client) Put(path string, data, resource interface{}) error {
-	return c.CreateAndDo("PUT", path, data, nil, nil, resource)
+	return c.PutWithContext(context.Background(), path, data, resource)
+}
+
+// PutWithContext is like Put but binds the request to ctx.
+func (c *Client) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "PUT", path, data, nil, nil, resource)
 }
 
 // Delete performs a DELETE request for the given path
 func (c *Client) Delete(path string) error {
-	return c.CreateAndDo("DELETE", path, nil, nil, nil, nil)
+	return c.DeleteWithContext(context.Background(), path)
+}
+
+// DeleteWithContext is like Delete but binds the request to ctx.
+func (c *Client) DeleteWithContext(ctx context.Context, path string) error {
+	return c.CreateAndDoWithContext(ctx, "DELETE", path, nil, nil, nil, nil)
 }
 
 // Upload performs a Upload request for the given path and saves the result in the
 // given resource.
 func (c *Client) Upload(relPath, fieldname, filename string, resource interface{}) error {
-	req, err := c.NewfileUploadRequest(relPath, fieldname, filename)
-	if err != nil {
-		return err
-	}
+	return c.UploadWithContext(context.Background(), relPath, fieldname, filename, resource)
+}
 
-	if _, err := c.doGetHeaders(req, resource, true); err != nil {
+// UploadWithContext is like Upload but binds the request to ctx.
+func (c *Client) UploadWithContext(ctx context.Context, relPath, fieldname, filename string, resource interface{}) error {
+	return c.UploadFormWithContext(ctx, relPath, fieldname, filename, nil, resource)
+}
+
+// UploadFormWithContext is like UploadWithContext and also sends params as
+// form fields next to the file. The access token is taken from the token
+// store like in CreateAndDoWithContext.
+func (c *Client) UploadFormWithContext(ctx context.Context, relPath, fieldname, filename string, params map[string]string, resource interface{}) error {
+	return c.withStoredToken(ctx, func(c *Client) error {
+		req, err := c.newFileUploadRequest(ctx, relPath, fieldname, filename, params)
+		if err != nil {
+			return err
+		}
+		_, err = c.doGetHeaders(req, resource, true)
 		return err
-	}
-	return nil
+	})
 }
 
 // Creates a new file upload http request with optional extra params
 func (c *Client) NewfileUploadRequest(relPath, paramName, filename string) (*http.Request, error) {
+	return c.NewfileUploadRequestWithContext(context.Background(), relPath, paramName, filename)
+}
+
+// NewfileUploadRequestWithContext is like NewfileUploadRequest but binds the
+// request to ctx.
+func (c *Client) NewfileUploadRequestWithContext(ctx context.Context, relPath, paramName, filename string) (*http.Request, error) {
+	return c.newFileUploadRequest(ctx, relPath, paramName, filename, nil)
+}
+
+func (c *Client) newFileUploadRequest(ctx context.Context, relPath, paramName, filename string, params map[string]string) (*http.Request, error) {
 	if strings.HasPrefix(relPath, "/") {
 		// make sure it's a relative path
 		relPath = strings.TrimLeft(relPath, "/")
@@ -556,6 +798,17 @@ func (c *Client) NewfileUploadRequest(relPath, paramName, filename string) (*htt
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := writer.WriteField(k, params[k]); err != nil {
+			return nil, err
+		}
+	}
+
 	part, err := writer.CreateFormFile(paramName, filepath.Base(filename))
 	if err != nil {
 		return nil, err
@@ -569,7 +822,7 @@ func (c *Client) NewfileUploadRequest(relPath, paramName, filename string) (*htt
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", uri, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,15 @@
 package goshopee
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
 
 	"github.com/caarlos0/env"
 	"github.com/jarcoal/httpmock"
@@ -61,4 +67,65 @@ func loadMockData(filename string, out interface{}) {
 	if err:=json.Unmarshal(f,&out);err!=nil {
 		panic(fmt.Sprintf("decode mock data error: %s", err))
 	}
-}
\ No newline at end of file
+}
+
+func Test_ConcurrentShops(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if q.Get("access_token") != "tok-"+q.Get("shop_id") {
+				return httpmock.NewStringResponse(403, `{"error":"error_auth","message":"token mismatch"}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_profile_resp.json")), nil
+		})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(sid uint64) {
+			defer wg.Done()
+			_, err := client.Shop.GetProfile(sid, fmt.Sprintf("tok-%d", sid))
+			errs <- err
+		}(uint64(i))
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Shop.GetProfile error: %s", err)
+		}
+	}
+
+	if client.ShopID != 0 || client.AccessToken != "" {
+		t.Errorf("shared client was modified: shop %d, token %q", client.ShopID, client.AccessToken)
+	}
+}
+
+func Test_ContextCancelsRetryBackoff(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(429, `{"error":"error_rate_limit","message":"too many requests"}`)
+			resp.Header.Set("Retry-After", "10")
+			return resp, nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Shop.GetProfileWithContext(ctx, shopID, accessToken)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shop.GetProfileWithContext returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shop.GetProfileWithContext took %s, expected to stop at the deadline", elapsed)
+	}
+}
@@ -0,0 +1,99 @@
+package goshopee
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Attempt is one attempt of an api call on its way through the
+// interceptors. Interceptors may replace Request, e.g. with
+// Request.WithContext to carry a tracing span.
+type Attempt struct {
+	Request    *http.Request
+	Path       string // api path without the /api/v2 prefix, e.g. /product/add_item
+	ShopID     uint64
+	MerchantID uint64
+	Number     int // counting from 1, greater for retries
+}
+
+// AttemptResult is the outcome of an Attempt. Response is nil when the
+// request could not be sent, Err is then the transport error. Otherwise Err
+// is the api error, if any, with its shopee ErrorCode.
+type AttemptResult struct {
+	Response  *http.Response
+	Err       error
+	ErrorCode string
+	RequestID string
+	Duration  time.Duration
+}
+
+// RoundTripFunc sends an attempt
+type RoundTripFunc func(a *Attempt) *AttemptResult
+
+// Interceptor wraps the sending of every attempt, to time it, count it or
+// trace it:
+//
+//	func metrics(next goshopee.RoundTripFunc) goshopee.RoundTripFunc {
+//		return func(a *goshopee.Attempt) *goshopee.AttemptResult {
+//			res := next(a)
+//			latency.WithLabelValues(a.Path, res.ErrorCode).Observe(res.Duration.Seconds())
+//			return res
+//		}
+//	}
+type Interceptor func(next RoundTripFunc) RoundTripFunc
+
+// roundTrip sends a through the interceptors of the client
+func (c *Client) roundTrip(a *Attempt) *AttemptResult {
+	send := c.send
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		send = c.interceptors[i](send)
+	}
+	return send(a)
+}
+
+// send makes the http request of a and checks the response for api errors
+func (c *Client) send(a *Attempt) *AttemptResult {
+	start := time.Now()
+	resp, err := c.Client.Do(a.Request)
+	res := &AttemptResult{Duration: time.Since(start)}
+	if err != nil {
+		// the url of the error carries the access token and sign
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = c.redactor.RedactURL(a.Request.URL)
+		}
+		res.Err = err
+		return res
+	}
+
+	res.Response = resp
+	res.Err = CheckResponseError(resp)
+
+	var body struct {
+		Error     string `json:"error"`
+		RequestID string `json:"request_id"`
+	}
+	json.Unmarshal(readBody(&resp.Body), &body)
+	res.RequestID = body.RequestID
+
+	var respErr ResponseError
+	var rateLimitErr RateLimitError
+	switch {
+	case errors.As(res.Err, &rateLimitErr):
+		res.ErrorCode = rateLimitErr.Code
+	case errors.As(res.Err, &respErr):
+		res.ErrorCode = respErr.Code
+	}
+	if res.Err != nil && res.ErrorCode == "" {
+		res.ErrorCode = body.Error
+	}
+	return res
+}
+
+func apiPath(p string) string {
+	return strings.TrimPrefix(p, "/api/v2")
+}
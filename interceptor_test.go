@@ -0,0 +1,85 @@
+package goshopee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+type spanKey struct{}
+
+func Test_Interceptors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var order []string
+	var spans []string
+	tracing := func(next RoundTripFunc) RoundTripFunc {
+		return func(a *Attempt) *AttemptResult {
+			order = append(order, "tracing")
+			span := fmt.Sprintf("%s#%d", a.Path, a.Number)
+			a.Request = a.Request.WithContext(context.WithValue(a.Request.Context(), spanKey{}, span))
+			res := next(a)
+			spans = append(spans, fmt.Sprintf("%s shop=%d code=%s request_id=%s", span, a.ShopID, res.ErrorCode, res.RequestID))
+			return res
+		}
+	}
+	metrics := func(next RoundTripFunc) RoundTripFunc {
+		return func(a *Attempt) *AttemptResult {
+			order = append(order, "metrics")
+			return next(a)
+		}
+	}
+
+	c := NewClient(app, WithRetry(1), WithInterceptors(tracing, metrics))
+	httpmock.ActivateNonDefault(c.Client)
+
+	var seen []interface{}
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			seen = append(seen, req.Context().Value(spanKey{}))
+			if len(seen) == 1 {
+				return httpmock.NewStringResponse(200, `{"error":"error_busy","message":"busy","request_id":"r1"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"request_id":"r2","response":{"shop_name":"x"}}`), nil
+		})
+
+	if _, err := c.Shop.GetProfile(shopID, accessToken); err != nil {
+		t.Fatalf("Shop.GetProfile error: %s", err)
+	}
+
+	if fmt.Sprint(order) != "[tracing metrics tracing metrics]" {
+		t.Errorf("interceptors ran in order %v", order)
+	}
+	if fmt.Sprint(seen) != "[/shop/get_profile#1 /shop/get_profile#2]" {
+		t.Errorf("transport saw spans %v", seen)
+	}
+	expected := fmt.Sprintf("[/shop/get_profile#1 shop=%d code=error_busy request_id=r1 /shop/get_profile#2 shop=%d code= request_id=r2]", shopID, shopID)
+	if fmt.Sprint(spans) != expected {
+		t.Errorf("spans %v, expected %s", spans, expected)
+	}
+}
+
+func Test_InterceptorShortCircuit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	offline := func(next RoundTripFunc) RoundTripFunc {
+		return func(a *Attempt) *AttemptResult {
+			return &AttemptResult{Err: fmt.Errorf("offline: %s", a.Path)}
+		}
+	}
+	c := NewClient(app, WithInterceptors(offline))
+	httpmock.ActivateNonDefault(c.Client)
+
+	_, err := c.Shop.GetProfile(shopID, accessToken)
+	if err == nil || err.Error() != "offline: /shop/get_profile" {
+		t.Errorf("Shop.GetProfile returned %v, expected the interceptor error", err)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 0 {
+		t.Errorf("%d requests sent, expected none", n)
+	}
+}
@@ -1,8 +1,10 @@
 package goshopee
 
-//
+import "context"
+
 type MediaSpaceService interface {
-	UploadImage(string) (*UploadImageResponse,error)
+	UploadImage(string) (*UploadImageResponse, error)
+	UploadImageWithContext(context.Context, string) (*UploadImageResponse, error)
 }
 
 // https://open.shopee.com/documents?module=91&type=1&id=660&version=2
@@ -17,23 +19,27 @@ type UploadImageResponseData struct {
 }
 
 type ImageInfo struct {
-	ImageID string `json:"image_id"`
+	ImageID      string     `json:"image_id"`
 	ImageURLList []ImageURL `json:"image_url_list"`
 }
 
 type ImageURL struct {
 	ImageURLRegion string `json:"image_url_region"`
-	ImageURL string `json:"image_url"`
+	ImageURL       string `json:"image_url"`
 }
 
 type MediaSpaceServiceOp struct {
 	client *Client
 }
 
-func (s *MediaSpaceServiceOp)UploadImage(filename string) (*UploadImageResponse,error){
+func (s *MediaSpaceServiceOp) UploadImage(filename string) (*UploadImageResponse, error) {
+	return s.UploadImageWithContext(context.Background(), filename)
+}
+
+func (s *MediaSpaceServiceOp) UploadImageWithContext(ctx context.Context, filename string) (*UploadImageResponse, error) {
 	path := "/media_space/upload_image"
-	
+
 	resp := new(UploadImageResponse)
-	err := s.client.Upload(path, "image", filename, resp)
+	err := s.client.UploadWithContext(ctx, path, "image", filename, resp)
 	return resp, err
-}
\ No newline at end of file
+}
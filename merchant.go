@@ -1,9 +1,12 @@
 package goshopee
 
+import "context"
+
 // https://open.shopee.com/documents/v2/v2.merchant.get_shop_list_by_merchant?module=93&type=1
 type MerchantService interface {
 	// GetMerchantInfo() (*GetMerchantInfoResponse, error)
 	GetShopListByMerchant(mid uint64, pageNo, pageSize int, tok string) (*GetShopListByMerchantResponse, error)
+	GetShopListByMerchantWithContext(ctx context.Context, mid uint64, pageNo, pageSize int, tok string) (*GetShopListByMerchantResponse, error)
 }
 
 type GetShopListByMerchantResponse struct {
@@ -35,6 +38,10 @@ type GetShopListByMerchantRequest struct {
 }
 
 func (s *MerchantServiceOp) GetShopListByMerchant(mid uint64, pageNo, pageSize int, tok string) (*GetShopListByMerchantResponse, error) {
+	return s.GetShopListByMerchantWithContext(context.Background(), mid, pageNo, pageSize, tok)
+}
+
+func (s *MerchantServiceOp) GetShopListByMerchantWithContext(ctx context.Context, mid uint64, pageNo, pageSize int, tok string) (*GetShopListByMerchantResponse, error) {
 	path := "/merchant/get_shop_list_by_merchant"
 
 	opt := GetShopListByMerchantRequest{
@@ -43,6 +50,6 @@ func (s *MerchantServiceOp) GetShopListByMerchant(mid uint64, pageNo, pageSize i
 	}
 
 	resp := new(GetShopListByMerchantResponse)
-	err := s.client.WithMerchant(mid, tok).Get(path, resp, opt)
+	err := s.client.WithMerchant(mid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
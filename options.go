@@ -1,6 +1,7 @@
 package goshopee
 
 import (
+	"log/slog"
 	"net/http"
 	"net/url"
 )
@@ -8,9 +9,20 @@ import (
 // Option is used to configure client with options
 type Option func(c *Client)
 
+// WithRetry retries failed requests up to retries times with the default
+// BackoffPolicy, making up to retries+1 attempts. It used to take the total
+// number of attempts: WithRetry(3) now makes 4 attempts instead of 3, pass
+// n-1 to keep the old count.
 func WithRetry(retries int) Option {
 	return func(c *Client) {
-		c.retries = retries
+		c.retry = NewBackoffPolicy(retries + 1)
+	}
+}
+
+// WithRetryPolicy lets policy decide which failed requests are retried
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
 	}
 }
 
@@ -20,6 +32,63 @@ func WithLogger(logger LeveledLoggerInterface) Option {
 	}
 }
 
+// WithSlog logs through logger, one structured entry per request attempt,
+// see SlogLogger
+func WithSlog(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.log = NewSlogLogger(logger)
+	}
+}
+
+// WithRedactor replaces DefaultRedactor for hiding secrets and buyer data
+// from the logs. A nil redactor logs everything as sent and received.
+func WithRedactor(r *Redactor) Option {
+	return func(c *Client) {
+		c.redactor = r
+	}
+}
+
+// WithTokenStore makes the client look up access tokens in store whenever a
+// service method is called with an empty token. Tokens are refreshed shortly
+// before they expire, or after Shopee rejects them, and the rotated refresh
+// token is saved back to store.
+func WithTokenStore(store TokenStore) Option {
+	return func(c *Client) {
+		c.tokens = newTokenManager(c, store)
+	}
+}
+
+// WithRateLimiter throttles the requests of the client with limiter before
+// they are sent. A limiter may be shared by several clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithInterceptors adds interceptors around the sending of every request,
+// retries included. The first interceptor is the outermost.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(c *Client) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// WithSigner signs the requests with signer instead of one made from the
+// App, e.g. to sign with a fixed clock in tests
+func WithSigner(signer *Signer) Option {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// WithTransport sends the requests of the client through rt, e.g. a Recorder
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.Client.Transport = rt
+	}
+}
+
 func WithProxy(proxyHost string) Option {
 	return func(c *Client) {
 		proxyURL, err := url.Parse(proxyHost)
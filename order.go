@@ -1,9 +1,36 @@
 package goshopee
 
-import "strings"
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
-type OrderService interface{
+type OrderService interface {
+	GetOrderList(uint64, GetOrderListRequest, string) (*GetOrderListResponse, error)
+	GetOrderListWithContext(context.Context, uint64, GetOrderListRequest, string) (*GetOrderListResponse, error)
+	GetShipmentList(uint64, GetShipmentListRequest, string) (*GetShipmentListResponse, error)
+	GetShipmentListWithContext(context.Context, uint64, GetShipmentListRequest, string) (*GetShipmentListResponse, error)
 	GetOrderDetail(uint64, []string, []string, string) (*GetOrderDetailResponse, error)
+	GetOrderDetailWithContext(context.Context, uint64, []string, []string, string) (*GetOrderDetailResponse, error)
+	GetOrderDetailFields(uint64, []string, string, ...OrderOptionalField) (*GetOrderDetailResponse, error)
+	GetOrderDetailFieldsWithContext(context.Context, uint64, []string, string, ...OrderOptionalField) (*GetOrderDetailResponse, error)
+	SyncOrders(context.Context, uint64, SyncOrdersRequest, string, func(Order) error) error
+	CancelOrder(uint64, CancelOrderRequest, string) (*CancelOrderResponse, error)
+	CancelOrderWithContext(context.Context, uint64, CancelOrderRequest, string) (*CancelOrderResponse, error)
+	HandleBuyerCancellation(uint64, HandleBuyerCancellationRequest, string) (*HandleBuyerCancellationResponse, error)
+	HandleBuyerCancellationWithContext(context.Context, uint64, HandleBuyerCancellationRequest, string) (*HandleBuyerCancellationResponse, error)
+	SetNote(uint64, string, string, string) (*SetNoteResponse, error)
+	SetNoteWithContext(context.Context, uint64, string, string, string) (*SetNoteResponse, error)
+	SplitOrder(uint64, SplitOrderRequest, string) (*SplitOrderResponse, error)
+	SplitOrderWithContext(context.Context, uint64, SplitOrderRequest, string) (*SplitOrderResponse, error)
+	UnsplitOrder(uint64, string, string) (*UnsplitOrderResponse, error)
+	UnsplitOrderWithContext(context.Context, uint64, string, string) (*UnsplitOrderResponse, error)
+	GetPendingBuyerInvoiceOrderList(uint64, GetPendingBuyerInvoiceOrderListRequest, string) (*GetPendingBuyerInvoiceOrderListResponse, error)
+	GetPendingBuyerInvoiceOrderListWithContext(context.Context, uint64, GetPendingBuyerInvoiceOrderListRequest, string) (*GetPendingBuyerInvoiceOrderListResponse, error)
+	UploadInvoiceDoc(uint64, UploadInvoiceDocRequest, string) (*UploadInvoiceDocResponse, error)
+	UploadInvoiceDocWithContext(context.Context, uint64, UploadInvoiceDocRequest, string) (*UploadInvoiceDocResponse, error)
 }
 
 type OrderServiceOp struct {
@@ -12,7 +39,7 @@ type OrderServiceOp struct {
 
 // https://open.shopee.com/documents?module=94&type=1&id=557&version=2
 type GetOrderDetailRequest struct {
-	OrderSNList string `url:"order_sn_list"`
+	OrderSNList            string `url:"order_sn_list"`
 	ResponseOptionalFields string `url:"response_optional_fields"`
 }
 
@@ -27,107 +54,524 @@ type GetOrderDetailResponseData struct {
 }
 
 type Order struct {
-	OrderSN string `json:"order_sn"`
-	Region string `json:"region"`
-	Currency string `json:"currency"`
-	COD bool `json:"cod"`
-	TotalAmount float64 `json:"total_amount"`
-	OrderStatus string `json:"order_status"`
-	ShippingCarrier string `json:"shipping_carrier"`
-	PaymentMethod string `json:"payment_method"`
-	EstimatedShippingFee float64 `json:"estimated_shipping_fee"`
-	MessageToSeller string `json:"message_to_seller"`
-	CreateTime int64 `json:"create_time"`
-	UpdateTime int64 `json:"update_time"`
-	DaysToShip int `json:"days_to_ship"`
-	ShipByDate int `json:"ship_by_date"`
-	BuyerUserID uint64 `json:"buyer_user_id"`
-	BuyerUsername string `json:"buyer_username"`
-	RecipientAddress OrderAddress `json:"recipient_address"`
-	ActualShippingFee float64 `json:"actual_shipping_fee"`
-	GoodsToDeclare bool `json:"goods_to_declare"`
-	Note string `json:"note"`
-	NoteUpdateTime int64 `json:"note_update_time"`
-	ItemList []OrderItem `json:"item_list"`
-	PayTime int64 `json:"pay_time"`
-	Dropshipper string `json:"dropshipper"`
-	CreditCardNumber string `json:"credit_card_number"`
-	DropshipperPhone string `json:"dropshipper_phone"`
-	SplitUp bool `json:"split_up"`
-	BuyerCancelReason string `json:"buyer_cancel_reason"`
-	CancelBy string `json:"cancel_by"`
-	CancelReason string `json:"cancel_reason"`
-	ActualShippingFeeConfirmed bool `json:"actual_shipping_fee_confirmed"`
-	BuyerCpfID string `json:"buyer_cpf_id"`
-	FulfillmentFlag string `json:"fulfillment_flag"`
-	PickupDoneTime int64 `json:"pickup_done_time"`
-	PackageList []OrderPackage `json:"package_list"`
-	InvoiceData Invoice `json:"invoice_data"`
-	CheckoutShippingCarrier string `json:"checkout_shipping_carrier"`
+	OrderSN                    string          `json:"order_sn"`
+	Region                     string          `json:"region"`
+	Currency                   string          `json:"currency"`
+	COD                        bool            `json:"cod"`
+	TotalAmount                float64         `json:"total_amount"`
+	OrderStatus                OrderStatus     `json:"order_status"`
+	ShippingCarrier            string          `json:"shipping_carrier"`
+	PaymentMethod              string          `json:"payment_method"`
+	EstimatedShippingFee       float64         `json:"estimated_shipping_fee"`
+	MessageToSeller            string          `json:"message_to_seller"`
+	CreateTime                 int64           `json:"create_time"`
+	UpdateTime                 int64           `json:"update_time"`
+	DaysToShip                 int             `json:"days_to_ship"`
+	ShipByDate                 int             `json:"ship_by_date"`
+	BuyerUserID                uint64          `json:"buyer_user_id"`
+	BuyerUsername              string          `json:"buyer_username"`
+	RecipientAddress           OrderAddress    `json:"recipient_address"`
+	ActualShippingFee          float64         `json:"actual_shipping_fee"`
+	GoodsToDeclare             bool            `json:"goods_to_declare"`
+	Note                       string          `json:"note"`
+	NoteUpdateTime             int64           `json:"note_update_time"`
+	ItemList                   []OrderItem     `json:"item_list"`
+	PayTime                    int64           `json:"pay_time"`
+	Dropshipper                string          `json:"dropshipper"`
+	CreditCardNumber           string          `json:"credit_card_number"`
+	DropshipperPhone           string          `json:"dropshipper_phone"`
+	SplitUp                    bool            `json:"split_up"`
+	BuyerCancelReason          string          `json:"buyer_cancel_reason"`
+	CancelBy                   string          `json:"cancel_by"`
+	CancelReason               string          `json:"cancel_reason"`
+	ActualShippingFeeConfirmed bool            `json:"actual_shipping_fee_confirmed"`
+	BuyerCpfID                 string          `json:"buyer_cpf_id"`
+	FulfillmentFlag            FulfillmentFlag `json:"fulfillment_flag"`
+	PickupDoneTime             int64           `json:"pickup_done_time"`
+	PackageList                []OrderPackage  `json:"package_list"`
+	InvoiceData                Invoice         `json:"invoice_data"`
+	CheckoutShippingCarrier    string          `json:"checkout_shipping_carrier"`
 }
 
 type Invoice struct {
-	Number string `json:"number"`
-	SeriesNumber string `json:"series_number"`
-	AccessKey string `json:"access_key"`
-	IssueDate int64 `json:"issue_date"`
-	TotalValue float64 `json:"total_value"`
+	Number             string  `json:"number"`
+	SeriesNumber       string  `json:"series_number"`
+	AccessKey          string  `json:"access_key"`
+	IssueDate          int64   `json:"issue_date"`
+	TotalValue         float64 `json:"total_value"`
 	ProductsTotalValue float64 `json:"products_total_value"`
-	TaxCode string `json:"tax_code"`
+	TaxCode            string  `json:"tax_code"`
 }
 
 type OrderPackage struct {
-	PackageNumber string `json:"package_number"`
-	LogisticsStatus string `json:"logistics_status"`
-	ShippingCarrier string `json:"shipping_carrier"`
-	ItemList []OrderPackageItem `json:"item_list"`
+	PackageNumber   string             `json:"package_number"`
+	LogisticsStatus LogisticsStatus    `json:"logistics_status"`
+	ShippingCarrier string             `json:"shipping_carrier"`
+	ItemList        []OrderPackageItem `json:"item_list"`
 }
 
 type OrderPackageItem struct {
-	ItemID uint64 `json:"item_id"`
+	ItemID  uint64 `json:"item_id"`
 	ModelID uint64 `json:"model_id"`
 }
 
 type OrderItem struct {
-	ItemID uint64 `json:"item_id"`
-	ItemName string `json:"item_name"`
-	ItemSKU string `json:"item_sku"`
-	ModelID uint64 `json:"model_id"`
-	ModelName string `json:"model_name"`
-	ModelSKU string `json:"model_sku"`
-	ModelQuantityPurchased int `json:"model_quantity_purchased"`
-	ModelOriginalPrice float64 `json:"model_original_price"`
-	ModelDiscountedPrice float64 `json:"model_discounted_price"`
-	Wholesale bool `json:"wholesale"`
-	Weight float64 `json:"weight"`
-	AddOnDeal bool `json:"add_on_deal"`
-	MainItem bool `json:"main_item"`
-	AddOnDealID uint64 `json:"add_on_deal_id"`
-	PromotionType string `json:"promotion_type"`
-	PromotionID uint64 `json:"promotion_id"`
+	ItemID                 uint64  `json:"item_id"`
+	ItemName               string  `json:"item_name"`
+	ItemSKU                string  `json:"item_sku"`
+	ModelID                uint64  `json:"model_id"`
+	ModelName              string  `json:"model_name"`
+	ModelSKU               string  `json:"model_sku"`
+	ModelQuantityPurchased int     `json:"model_quantity_purchased"`
+	ModelOriginalPrice     float64 `json:"model_original_price"`
+	ModelDiscountedPrice   float64 `json:"model_discounted_price"`
+	Wholesale              bool    `json:"wholesale"`
+	Weight                 float64 `json:"weight"`
+	AddOnDeal              bool    `json:"add_on_deal"`
+	MainItem               bool    `json:"main_item"`
+	AddOnDealID            uint64  `json:"add_on_deal_id"`
+	PromotionType          string  `json:"promotion_type"`
+	PromotionID            uint64  `json:"promotion_id"`
 }
 
 type OrderAddress struct {
-	Name string `json:"name"`
-	Phone string `json:"phone"`
-	Town string `json:"town"`
-	District string `json:"district"`
-	City string `json:"city"`
-	State string `json:"state"`
-	Region string `json:"region"`
-	Zipcode string `json:"zipcode"`
+	Name        string `json:"name"`
+	Phone       string `json:"phone"`
+	Town        string `json:"town"`
+	District    string `json:"district"`
+	City        string `json:"city"`
+	State       string `json:"state"`
+	Region      string `json:"region"`
+	Zipcode     string `json:"zipcode"`
 	FullAddress string `json:"full_address"`
 }
 
-func (s *OrderServiceOp)GetOrderDetail(sid uint64, snlist, fields []string, tok string) (*GetOrderDetailResponse, error) {
+func (s *OrderServiceOp) GetOrderDetail(sid uint64, snlist, fields []string, tok string) (*GetOrderDetailResponse, error) {
+	return s.GetOrderDetailWithContext(context.Background(), sid, snlist, fields, tok)
+}
+
+// GetOrderDetailWithContext returns the details of the orders in snlist,
+// with the optional fields sent as named. Use GetOrderDetailFields to have
+// them checked, this one is for fields without an OrderField constant yet.
+func (s *OrderServiceOp) GetOrderDetailWithContext(ctx context.Context, sid uint64, snlist, fields []string, tok string) (*GetOrderDetailResponse, error) {
 	path := "/order/get_order_detail"
 
-	opt:=GetOrderDetailRequest{
-		OrderSNList: strings.Join(snlist,","),
-		ResponseOptionalFields: strings.Join(fields,","),
+	opt := GetOrderDetailRequest{
+		OrderSNList:            strings.Join(snlist, ","),
+		ResponseOptionalFields: strings.Join(fields, ","),
 	}
 
 	resp := new(GetOrderDetailResponse)
-	err := s.client.WithShop(sid,tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+func (s *OrderServiceOp) GetOrderDetailFields(sid uint64, snlist []string, tok string, fields ...OrderOptionalField) (*GetOrderDetailResponse, error) {
+	return s.GetOrderDetailFieldsWithContext(context.Background(), sid, snlist, tok, fields...)
+}
+
+// GetOrderDetailFieldsWithContext is like GetOrderDetailWithContext with the
+// optional fields, e.g. AllOrderOptionalFields, checked. It fails without
+// calling shopee on an unknown field, which shopee would silently ignore.
+func (s *OrderServiceOp) GetOrderDetailFieldsWithContext(ctx context.Context, sid uint64, snlist []string, tok string, fields ...OrderOptionalField) (*GetOrderDetailResponse, error) {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		if !f.Valid() {
+			return nil, fmt.Errorf("unknown order optional field %q", f)
+		}
+		names[i] = string(f)
+	}
+	return s.GetOrderDetailWithContext(ctx, sid, snlist, names, tok)
+}
+
+const (
+	OrderTimeRangeCreateTime = "create_time"
+	OrderTimeRangeUpdateTime = "update_time"
+
+	// shopee limits of order list and order detail
+	OrderListMaxTimeRange = 15 * 24 * 60 * 60 // seconds
+	OrderListMaxPageSize  = 100
+	OrderDetailMaxSNList  = 50
+)
+
+// https://open.shopee.com/documents/v2/v2.order.get_order_list?module=94&type=1
+type GetOrderListRequest struct {
+	TimeRangeField         string      `url:"time_range_field"`
+	TimeFrom               int64       `url:"time_from"`
+	TimeTo                 int64       `url:"time_to"`
+	PageSize               int         `url:"page_size"`
+	Cursor                 string      `url:"cursor,omitempty"`
+	OrderStatus            OrderStatus `url:"order_status,omitempty"`
+	ResponseOptionalFields string      `url:"response_optional_fields,omitempty"`
+}
+
+type GetOrderListResponse struct {
+	BaseResponse
+
+	Response GetOrderListResponseData `json:"response"`
+}
+
+type GetOrderListResponseData struct {
+	More       bool                           `json:"more"`
+	NextCursor string                         `json:"next_cursor"`
+	OrderList  []GetOrderListResponseDataItem `json:"order_list"`
+}
+
+type GetOrderListResponseDataItem struct {
+	OrderSN     string      `json:"order_sn"`
+	OrderStatus OrderStatus `json:"order_status"` // only with response_optional_fields=order_status
+}
+
+func (s *OrderServiceOp) GetOrderList(sid uint64, opt GetOrderListRequest, tok string) (*GetOrderListResponse, error) {
+	return s.GetOrderListWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *OrderServiceOp) GetOrderListWithContext(ctx context.Context, sid uint64, opt GetOrderListRequest, tok string) (*GetOrderListResponse, error) {
+	path := "/order/get_order_list"
+
+	resp := new(GetOrderListResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.order.get_shipment_list?module=94&type=1
+type GetShipmentListRequest struct {
+	PageSize int    `url:"page_size"`
+	Cursor   string `url:"cursor,omitempty"`
+}
+
+type GetShipmentListResponse struct {
+	BaseResponse
+
+	Response GetShipmentListResponseData `json:"response"`
+}
+
+type GetShipmentListResponseData struct {
+	More       bool                              `json:"more"`
+	NextCursor string                            `json:"next_cursor"`
+	OrderList  []GetShipmentListResponseDataItem `json:"order_list"`
+}
+
+type GetShipmentListResponseDataItem struct {
+	OrderSN       string `json:"order_sn"`
+	PackageNumber string `json:"package_number"`
+}
+
+func (s *OrderServiceOp) GetShipmentList(sid uint64, opt GetShipmentListRequest, tok string) (*GetShipmentListResponse, error) {
+	return s.GetShipmentListWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *OrderServiceOp) GetShipmentListWithContext(ctx context.Context, sid uint64, opt GetShipmentListRequest, tok string) (*GetShipmentListResponse, error) {
+	path := "/order/get_shipment_list"
+
+	resp := new(GetShipmentListResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// SyncOrdersRequest selects the orders walked by SyncOrders. TimeFrom and
+// TimeTo may span any range, it is split into the 15-day windows accepted by
+// get_order_list.
+type SyncOrdersRequest struct {
+	TimeRangeField         string // OrderTimeRangeCreateTime or OrderTimeRangeUpdateTime
+	TimeFrom               int64
+	TimeTo                 int64
+	OrderStatus            OrderStatus          // optional
+	PageSize               int                  // defaults to OrderListMaxPageSize
+	ResponseOptionalFields []OrderOptionalField // passed to get_order_detail
+}
+
+// SyncOrders lists every order of the shop matching opt and calls fn with its
+// details, in the order returned by shopee, once per order even when it shows
+// up in several windows. Details are fetched in batches of
+// OrderDetailMaxSNList. It stops at the first error, either from the API or
+// returned by fn.
+func (s *OrderServiceOp) SyncOrders(ctx context.Context, sid uint64, opt SyncOrdersRequest, tok string, fn func(Order) error) error {
+	if opt.TimeRangeField != OrderTimeRangeCreateTime && opt.TimeRangeField != OrderTimeRangeUpdateTime {
+		return fmt.Errorf("%w: invalid time range field %q, expected %s or %s", ErrValidation, opt.TimeRangeField, OrderTimeRangeCreateTime, OrderTimeRangeUpdateTime)
+	}
+	if opt.TimeFrom > opt.TimeTo {
+		return fmt.Errorf("%w: time_from %d after time_to %d", ErrValidation, opt.TimeFrom, opt.TimeTo)
+	}
+	pageSize := opt.PageSize
+	if pageSize <= 0 || pageSize > OrderListMaxPageSize {
+		pageSize = OrderListMaxPageSize
+	}
+
+	// flush fetches the details of the buffered SNs in full batches, and
+	// also the last partial batch when all is set
+	var snlist []string
+	seen := map[string]bool{}
+	flush := func(all bool) error {
+		for len(snlist) >= OrderDetailMaxSNList || (all && len(snlist) > 0) {
+			n := len(snlist)
+			if n > OrderDetailMaxSNList {
+				n = OrderDetailMaxSNList
+			}
+			res, err := s.GetOrderDetailFieldsWithContext(ctx, sid, snlist[:n], tok, opt.ResponseOptionalFields...)
+			if err != nil {
+				return err
+			}
+			for _, order := range res.Response.OrderList {
+				if err := fn(order); err != nil {
+					return err
+				}
+			}
+			snlist = snlist[n:]
+		}
+		return nil
+	}
+
+	for from := opt.TimeFrom; from <= opt.TimeTo; from += OrderListMaxTimeRange + 1 {
+		to := from + OrderListMaxTimeRange
+		if to > opt.TimeTo {
+			to = opt.TimeTo
+		}
+
+		req := GetOrderListRequest{
+			TimeRangeField: opt.TimeRangeField,
+			TimeFrom:       from,
+			TimeTo:         to,
+			PageSize:       pageSize,
+			OrderStatus:    opt.OrderStatus,
+		}
+		err := NewOrderListPager(s, sid, req, tok).Each(ctx, func(o GetOrderListResponseDataItem) error {
+			if seen[o.OrderSN] {
+				return nil
+			}
+			seen[o.OrderSN] = true
+			snlist = append(snlist, o.OrderSN)
+			return flush(false)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return flush(true)
+}
+
+// https://open.shopee.com/documents/v2/v2.order.cancel_order?module=94&type=1
+const (
+	CancelReasonOutOfStock        = "OUT_OF_STOCK"
+	CancelReasonCustomerRequest   = "CUSTOMER_REQUEST"
+	CancelReasonUndeliverableArea = "UNDELIVERABLE_AREA"
+	CancelReasonCODNotSupported   = "COD_NOT_SUPPORTED"
+)
+
+type CancelOrderRequest struct {
+	OrderSN      string                   `json:"order_sn"`
+	CancelReason string                   `json:"cancel_reason"`
+	ItemList     []CancelOrderRequestItem `json:"item_list,omitempty"` // required for OUT_OF_STOCK
+}
+
+type CancelOrderRequestItem struct {
+	ItemID  uint64 `json:"item_id"`
+	ModelID uint64 `json:"model_id"`
+}
+
+type CancelOrderResponse struct {
+	BaseResponse
+
+	Response CancelOrderResponseData `json:"response"`
+}
+
+type CancelOrderResponseData struct {
+	UpdateTime int64 `json:"update_time"`
+}
+
+func (s *OrderServiceOp) CancelOrder(sid uint64, data CancelOrderRequest, tok string) (*CancelOrderResponse, error) {
+	return s.CancelOrderWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *OrderServiceOp) CancelOrderWithContext(ctx context.Context, sid uint64, data CancelOrderRequest, tok string) (*CancelOrderResponse, error) {
+	path := "/order/cancel_order"
+	resp := new(CancelOrderResponse)
+	req, err := StructToMap(data)
+	if err != nil {
+		return nil, err
+	}
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
-}
\ No newline at end of file
+}
+
+// https://open.shopee.com/documents/v2/v2.order.handle_buyer_cancellation?module=94&type=1
+const (
+	BuyerCancellationAccept = "ACCEPT"
+	BuyerCancellationReject = "REJECT"
+)
+
+type HandleBuyerCancellationRequest struct {
+	OrderSN   string `json:"order_sn"`
+	Operation string `json:"operation"`
+}
+
+type HandleBuyerCancellationResponse struct {
+	BaseResponse
+
+	Response HandleBuyerCancellationResponseData `json:"response"`
+}
+
+type HandleBuyerCancellationResponseData struct {
+	UpdateTime int64 `json:"update_time"`
+}
+
+func (s *OrderServiceOp) HandleBuyerCancellation(sid uint64, data HandleBuyerCancellationRequest, tok string) (*HandleBuyerCancellationResponse, error) {
+	return s.HandleBuyerCancellationWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *OrderServiceOp) HandleBuyerCancellationWithContext(ctx context.Context, sid uint64, data HandleBuyerCancellationRequest, tok string) (*HandleBuyerCancellationResponse, error) {
+	path := "/order/handle_buyer_cancellation"
+	resp := new(HandleBuyerCancellationResponse)
+	req, err := StructToMap(data)
+	if err != nil {
+		return nil, err
+	}
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.order.set_note?module=94&type=1
+type SetNoteResponse struct {
+	BaseResponse
+}
+
+func (s *OrderServiceOp) SetNote(sid uint64, ordersn, note, tok string) (*SetNoteResponse, error) {
+	return s.SetNoteWithContext(context.Background(), sid, ordersn, note, tok)
+}
+
+func (s *OrderServiceOp) SetNoteWithContext(ctx context.Context, sid uint64, ordersn, note, tok string) (*SetNoteResponse, error) {
+	path := "/order/set_note"
+	req := map[string]interface{}{
+		"order_sn": ordersn,
+		"note":     note,
+	}
+	resp := new(SetNoteResponse)
+	err := s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.order.split_order?module=94&type=1
+type SplitOrderRequest struct {
+	OrderSN     string                     `json:"order_sn"`
+	PackageList []SplitOrderRequestPackage `json:"package_list"`
+}
+
+type SplitOrderRequestPackage struct {
+	ItemList []SplitOrderRequestItem `json:"item_list"`
+}
+
+type SplitOrderRequestItem struct {
+	ItemID           uint64 `json:"item_id"`
+	ModelID          uint64 `json:"model_id"`
+	OrderItemID      uint64 `json:"order_item_id,omitempty"`
+	PromotionGroupID uint64 `json:"promotion_group_id,omitempty"`
+}
+
+type SplitOrderResponse struct {
+	BaseResponse
+
+	Response SplitOrderResponseData `json:"response"`
+}
+
+type SplitOrderResponseData struct {
+	OrderSN     string         `json:"order_sn"`
+	PackageList []OrderPackage `json:"package_list"`
+}
+
+func (s *OrderServiceOp) SplitOrder(sid uint64, data SplitOrderRequest, tok string) (*SplitOrderResponse, error) {
+	return s.SplitOrderWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *OrderServiceOp) SplitOrderWithContext(ctx context.Context, sid uint64, data SplitOrderRequest, tok string) (*SplitOrderResponse, error) {
+	path := "/order/split_order"
+	resp := new(SplitOrderResponse)
+	req, err := StructToMap(data)
+	if err != nil {
+		return nil, err
+	}
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.order.unsplit_order?module=94&type=1
+type UnsplitOrderResponse struct {
+	BaseResponse
+}
+
+func (s *OrderServiceOp) UnsplitOrder(sid uint64, ordersn, tok string) (*UnsplitOrderResponse, error) {
+	return s.UnsplitOrderWithContext(context.Background(), sid, ordersn, tok)
+}
+
+func (s *OrderServiceOp) UnsplitOrderWithContext(ctx context.Context, sid uint64, ordersn, tok string) (*UnsplitOrderResponse, error) {
+	path := "/order/unsplit_order"
+	req := map[string]interface{}{
+		"order_sn": ordersn,
+	}
+	resp := new(UnsplitOrderResponse)
+	err := s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.order.get_pending_buyer_invoice_order_list?module=94&type=1
+type GetPendingBuyerInvoiceOrderListRequest struct {
+	PageSize int    `url:"page_size"`
+	Cursor   string `url:"cursor,omitempty"`
+}
+
+type GetPendingBuyerInvoiceOrderListResponse struct {
+	BaseResponse
+
+	Response GetPendingBuyerInvoiceOrderListResponseData `json:"response"`
+}
+
+type GetPendingBuyerInvoiceOrderListResponseData struct {
+	OrderSNList []string `json:"order_sn_list"`
+	More        bool     `json:"more"`
+	NextCursor  string   `json:"next_cursor"`
+}
+
+func (s *OrderServiceOp) GetPendingBuyerInvoiceOrderList(sid uint64, opt GetPendingBuyerInvoiceOrderListRequest, tok string) (*GetPendingBuyerInvoiceOrderListResponse, error) {
+	return s.GetPendingBuyerInvoiceOrderListWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *OrderServiceOp) GetPendingBuyerInvoiceOrderListWithContext(ctx context.Context, sid uint64, opt GetPendingBuyerInvoiceOrderListRequest, tok string) (*GetPendingBuyerInvoiceOrderListResponse, error) {
+	path := "/order/get_pending_buyer_invoice_order_list"
+
+	resp := new(GetPendingBuyerInvoiceOrderListResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.order.upload_invoice_doc?module=94&type=1
+const (
+	InvoiceFileTypePDF  = 1
+	InvoiceFileTypeJPEG = 2
+	InvoiceFileTypePNG  = 3
+)
+
+type UploadInvoiceDocRequest struct {
+	OrderSN  string
+	FileType int
+	Filename string // path of the invoice file to upload
+}
+
+type UploadInvoiceDocResponse struct {
+	BaseResponse
+}
+
+func (s *OrderServiceOp) UploadInvoiceDoc(sid uint64, data UploadInvoiceDocRequest, tok string) (*UploadInvoiceDocResponse, error) {
+	return s.UploadInvoiceDocWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *OrderServiceOp) UploadInvoiceDocWithContext(ctx context.Context, sid uint64, data UploadInvoiceDocRequest, tok string) (*UploadInvoiceDocResponse, error) {
+	path := "/order/upload_invoice_doc"
+	params := map[string]string{
+		"order_sn":  data.OrderSN,
+		"file_type": strconv.Itoa(data.FileType),
+	}
+
+	resp := new(UploadInvoiceDocResponse)
+	err := s.client.WithShop(sid, tok).UploadFormWithContext(ctx, path, "file", data.Filename, params, resp)
+	return resp, err
+}
@@ -0,0 +1,219 @@
+package goshopee
+
+import "fmt"
+
+// OrderStatus is the status of an order
+// https://open.shopee.com/documents/v2/v2.order.get_order_detail?module=94&type=1
+type OrderStatus string
+
+const (
+	OrderStatusUnpaid           OrderStatus = "UNPAID"
+	OrderStatusInvoicePending   OrderStatus = "INVOICE_PENDING"
+	OrderStatusReadyToShip      OrderStatus = "READY_TO_SHIP"
+	OrderStatusProcessed        OrderStatus = "PROCESSED"
+	OrderStatusRetryShip        OrderStatus = "RETRY_SHIP"
+	OrderStatusShipped          OrderStatus = "SHIPPED"
+	OrderStatusToConfirmReceive OrderStatus = "TO_CONFIRM_RECEIVE"
+	OrderStatusInCancel         OrderStatus = "IN_CANCEL"
+	OrderStatusCancelled        OrderStatus = "CANCELLED"
+	OrderStatusToReturn         OrderStatus = "TO_RETURN"
+	OrderStatusCompleted        OrderStatus = "COMPLETED"
+)
+
+// orderStatusNext lists the statuses an order may move to directly from each
+// status. IN_CANCEL goes back to where it came from when the seller rejects the
+// cancellation.
+var orderStatusNext = map[OrderStatus][]OrderStatus{
+	OrderStatusUnpaid:           {OrderStatusInvoicePending, OrderStatusReadyToShip, OrderStatusInCancel, OrderStatusCancelled},
+	OrderStatusInvoicePending:   {OrderStatusReadyToShip, OrderStatusInCancel, OrderStatusCancelled},
+	OrderStatusReadyToShip:      {OrderStatusProcessed, OrderStatusShipped, OrderStatusInCancel, OrderStatusCancelled},
+	OrderStatusProcessed:        {OrderStatusRetryShip, OrderStatusShipped, OrderStatusInCancel, OrderStatusCancelled},
+	OrderStatusRetryShip:        {OrderStatusProcessed, OrderStatusShipped, OrderStatusInCancel, OrderStatusCancelled},
+	OrderStatusInCancel:         {OrderStatusReadyToShip, OrderStatusProcessed, OrderStatusCancelled},
+	OrderStatusShipped:          {OrderStatusToConfirmReceive, OrderStatusToReturn, OrderStatusCompleted, OrderStatusCancelled},
+	OrderStatusToConfirmReceive: {OrderStatusToReturn, OrderStatusCompleted},
+	OrderStatusToReturn:         {OrderStatusCompleted, OrderStatusCancelled},
+	OrderStatusCompleted:        nil,
+	OrderStatusCancelled:        nil,
+}
+
+// Valid reports whether s is a status known to this package.
+func (s OrderStatus) Valid() bool {
+	_, ok := orderStatusNext[s]
+	return ok
+}
+
+// CanShip reports whether the order is waiting to be shipped by the seller.
+func (s OrderStatus) CanShip() bool {
+	return s == OrderStatusReadyToShip || s == OrderStatusRetryShip
+}
+
+// CanCancel reports whether the seller can still cancel the order.
+func (s OrderStatus) CanCancel() bool {
+	switch s {
+	case OrderStatusUnpaid, OrderStatusInvoicePending, OrderStatusReadyToShip, OrderStatusProcessed, OrderStatusRetryShip:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether the order can not change status anymore.
+func (s OrderStatus) IsTerminal() bool {
+	return s == OrderStatusCompleted || s == OrderStatusCancelled
+}
+
+// CanTransitionTo reports whether an order in status s may later be seen in
+// status next, possibly through statuses in between. Staying in the same
+// status is always allowed.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	if s == next {
+		return true
+	}
+
+	seen := map[OrderStatus]bool{s: true}
+	queue := []OrderStatus{s}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, n := range orderStatusNext[cur] {
+			if n == next {
+				return true
+			}
+			if !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return false
+}
+
+// OrderTransitionError reports an illegal change between two snapshots of
+// the same order.
+type OrderTransitionError struct {
+	OrderSN string
+	From    OrderStatus
+	To      OrderStatus
+	Reason  string
+}
+
+func (e OrderTransitionError) Error() string {
+	return fmt.Sprintf("order %s: illegal transition %s -> %s: %s", e.OrderSN, e.From, e.To, e.Reason)
+}
+
+// ValidateOrderTransition checks that next is a possible later snapshot of
+// prev. It returns an OrderTransitionError if the snapshots belong to
+// different orders, next is older than prev, or the status moved in a way
+// shopee never does, e.g. out of COMPLETED.
+func ValidateOrderTransition(prev, next Order) error {
+	e := OrderTransitionError{OrderSN: prev.OrderSN, From: prev.OrderStatus, To: next.OrderStatus}
+
+	switch {
+	case prev.OrderSN != next.OrderSN:
+		e.Reason = fmt.Sprintf("order sn changed to %s", next.OrderSN)
+	case next.UpdateTime < prev.UpdateTime:
+		e.Reason = fmt.Sprintf("update time went back from %d to %d", prev.UpdateTime, next.UpdateTime)
+	case !prev.OrderStatus.Valid():
+		e.Reason = fmt.Sprintf("unknown status %s", prev.OrderStatus)
+	case !next.OrderStatus.Valid():
+		e.Reason = fmt.Sprintf("unknown status %s", next.OrderStatus)
+	case !prev.OrderStatus.CanTransitionTo(next.OrderStatus):
+		e.Reason = "status can not be reached"
+	default:
+		return nil
+	}
+	return e
+}
+
+// LogisticsStatus is the logistics status of an order package
+type LogisticsStatus string
+
+const (
+	LogisticsStatusNotStart         LogisticsStatus = "LOGISTICS_NOT_START"
+	LogisticsStatusPendingArrange   LogisticsStatus = "LOGISTICS_PENDING_ARRANGE"
+	LogisticsStatusReady            LogisticsStatus = "LOGISTICS_READY"
+	LogisticsStatusRequestCreated   LogisticsStatus = "LOGISTICS_REQUEST_CREATED"
+	LogisticsStatusPickupDone       LogisticsStatus = "LOGISTICS_PICKUP_DONE"
+	LogisticsStatusPickupRetry      LogisticsStatus = "LOGISTICS_PICKUP_RETRY"
+	LogisticsStatusPickupFailed     LogisticsStatus = "LOGISTICS_PICKUP_FAILED"
+	LogisticsStatusDeliveryDone     LogisticsStatus = "LOGISTICS_DELIVERY_DONE"
+	LogisticsStatusDeliveryFailed   LogisticsStatus = "LOGISTICS_DELIVERY_FAILED"
+	LogisticsStatusRequestCancelled LogisticsStatus = "LOGISTICS_REQUEST_CANCELED"
+	LogisticsStatusCODRejected      LogisticsStatus = "LOGISTICS_COD_REJECTED"
+	LogisticsStatusLost             LogisticsStatus = "LOGISTICS_LOST"
+	LogisticsStatusInvalid          LogisticsStatus = "LOGISTICS_INVALID"
+)
+
+// IsTerminal reports whether the package will not move anymore.
+func (s LogisticsStatus) IsTerminal() bool {
+	switch s {
+	case LogisticsStatusDeliveryDone, LogisticsStatusDeliveryFailed, LogisticsStatusRequestCancelled,
+		LogisticsStatusCODRejected, LogisticsStatusLost, LogisticsStatusInvalid:
+		return true
+	}
+	return false
+}
+
+// FulfillmentFlag tells who fulfills an order
+type FulfillmentFlag string
+
+const (
+	FulfillmentFlagShopee      FulfillmentFlag = "fulfilled_by_shopee"
+	FulfillmentFlagCBSeller    FulfillmentFlag = "fulfilled_by_cb_seller"
+	FulfillmentFlagLocalSeller FulfillmentFlag = "fulfilled_by_local_seller"
+)
+
+// OrderOptionalField is a field of Order only returned by get_order_detail
+// when requested in response_optional_fields
+type OrderOptionalField string
+
+const (
+	OrderFieldBuyerUserID                OrderOptionalField = "buyer_user_id"
+	OrderFieldBuyerUsername              OrderOptionalField = "buyer_username"
+	OrderFieldEstimatedShippingFee       OrderOptionalField = "estimated_shipping_fee"
+	OrderFieldRecipientAddress           OrderOptionalField = "recipient_address"
+	OrderFieldActualShippingFee          OrderOptionalField = "actual_shipping_fee"
+	OrderFieldGoodsToDeclare             OrderOptionalField = "goods_to_declare"
+	OrderFieldNote                       OrderOptionalField = "note"
+	OrderFieldNoteUpdateTime             OrderOptionalField = "note_update_time"
+	OrderFieldItemList                   OrderOptionalField = "item_list"
+	OrderFieldPayTime                    OrderOptionalField = "pay_time"
+	OrderFieldDropshipper                OrderOptionalField = "dropshipper"
+	OrderFieldDropshipperPhone           OrderOptionalField = "dropshipper_phone"
+	OrderFieldCreditCardNumber           OrderOptionalField = "credit_card_number"
+	OrderFieldSplitUp                    OrderOptionalField = "split_up"
+	OrderFieldBuyerCancelReason          OrderOptionalField = "buyer_cancel_reason"
+	OrderFieldCancelBy                   OrderOptionalField = "cancel_by"
+	OrderFieldCancelReason               OrderOptionalField = "cancel_reason"
+	OrderFieldActualShippingFeeConfirmed OrderOptionalField = "actual_shipping_fee_confirmed"
+	OrderFieldBuyerCpfID                 OrderOptionalField = "buyer_cpf_id"
+	OrderFieldFulfillmentFlag            OrderOptionalField = "fulfillment_flag"
+	OrderFieldPickupDoneTime             OrderOptionalField = "pickup_done_time"
+	OrderFieldPackageList                OrderOptionalField = "package_list"
+	OrderFieldShippingCarrier            OrderOptionalField = "shipping_carrier"
+	OrderFieldPaymentMethod              OrderOptionalField = "payment_method"
+	OrderFieldTotalAmount                OrderOptionalField = "total_amount"
+	OrderFieldInvoiceData                OrderOptionalField = "invoice_data"
+	OrderFieldCheckoutShippingCarrier    OrderOptionalField = "checkout_shipping_carrier"
+)
+
+// AllOrderOptionalFields requests every optional field of get_order_detail.
+var AllOrderOptionalFields = []OrderOptionalField{
+	OrderFieldBuyerUserID, OrderFieldBuyerUsername, OrderFieldEstimatedShippingFee, OrderFieldRecipientAddress,
+	OrderFieldActualShippingFee, OrderFieldGoodsToDeclare, OrderFieldNote, OrderFieldNoteUpdateTime,
+	OrderFieldItemList, OrderFieldPayTime, OrderFieldDropshipper, OrderFieldDropshipperPhone,
+	OrderFieldCreditCardNumber, OrderFieldSplitUp, OrderFieldBuyerCancelReason, OrderFieldCancelBy,
+	OrderFieldCancelReason, OrderFieldActualShippingFeeConfirmed, OrderFieldBuyerCpfID, OrderFieldFulfillmentFlag,
+	OrderFieldPickupDoneTime, OrderFieldPackageList, OrderFieldShippingCarrier, OrderFieldPaymentMethod,
+	OrderFieldTotalAmount, OrderFieldInvoiceData, OrderFieldCheckoutShippingCarrier,
+}
+
+// Valid reports whether f is one of the OrderField constants
+func (f OrderOptionalField) Valid() bool {
+	for _, known := range AllOrderOptionalFields {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
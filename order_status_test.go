@@ -0,0 +1,101 @@
+package goshopee
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_OrderStatusPredicates(t *testing.T) {
+	cases := []struct {
+		status     OrderStatus
+		canShip    bool
+		isTerminal bool
+	}{
+		{OrderStatusUnpaid, false, false},
+		{OrderStatusReadyToShip, true, false},
+		{OrderStatusRetryShip, true, false},
+		{OrderStatusShipped, false, false},
+		{OrderStatusCompleted, false, true},
+		{OrderStatusCancelled, false, true},
+	}
+
+	for _, c := range cases {
+		if c.status.CanShip() != c.canShip {
+			t.Errorf("%s.CanShip() returned %v, expected %v", c.status, !c.canShip, c.canShip)
+		}
+		if c.status.IsTerminal() != c.isTerminal {
+			t.Errorf("%s.IsTerminal() returned %v, expected %v", c.status, !c.isTerminal, c.isTerminal)
+		}
+	}
+}
+
+func Test_ValidateOrderTransition(t *testing.T) {
+	cases := []struct {
+		from, to OrderStatus
+		ok       bool
+	}{
+		{OrderStatusUnpaid, OrderStatusReadyToShip, true},
+		{OrderStatusUnpaid, OrderStatusCompleted, true},
+		{OrderStatusInCancel, OrderStatusReadyToShip, true},
+		{OrderStatusShipped, OrderStatusShipped, true},
+		{OrderStatusCompleted, OrderStatusReadyToShip, false},
+		{OrderStatusCancelled, OrderStatusShipped, false},
+		{OrderStatusShipped, OrderStatusReadyToShip, false},
+		{OrderStatusReadyToShip, "LOST_IN_SPACE", false},
+	}
+
+	for _, c := range cases {
+		prev := Order{OrderSN: "SN123", OrderStatus: c.from, UpdateTime: 1}
+		next := Order{OrderSN: "SN123", OrderStatus: c.to, UpdateTime: 2}
+		err := ValidateOrderTransition(prev, next)
+		if (err == nil) != c.ok {
+			t.Errorf("ValidateOrderTransition(%s, %s) returned %v, expected ok %v", c.from, c.to, err, c.ok)
+		}
+	}
+
+	prev := Order{OrderSN: "SN123", OrderStatus: OrderStatusUnpaid, UpdateTime: 2}
+	next := Order{OrderSN: "SN123", OrderStatus: OrderStatusReadyToShip, UpdateTime: 1}
+	if err := ValidateOrderTransition(prev, next); err == nil {
+		t.Errorf("ValidateOrderTransition accepted an older snapshot")
+	}
+}
+
+func Test_GetOrderDetailOptionalFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var fields string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_detail", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			fields = req.URL.Query().Get("response_optional_fields")
+			return httpmock.NewBytesResponse(200, loadFixture("get_order_detail_resp.json")), nil
+		})
+
+	// fields added by shopee after this release are sent as is
+	requested := []string{string(OrderFieldBuyerUserID), string(OrderFieldItemList), "new_field"}
+	if _, err := client.Order.GetOrderDetail(shopID, []string{"SN123"}, requested, accessToken); err != nil {
+		t.Fatalf("Order.GetOrderDetail error: %s", err)
+	}
+	if fields != "buyer_user_id,item_list,new_field" {
+		t.Errorf("response_optional_fields sent as %q", fields)
+	}
+
+	if _, err := client.Order.GetOrderDetailFields(shopID, []string{"SN123"}, accessToken, AllOrderOptionalFields...); err != nil {
+		t.Fatalf("Order.GetOrderDetailFields error: %s", err)
+	}
+	if strings.Count(fields, ",") != len(AllOrderOptionalFields)-1 {
+		t.Errorf("response_optional_fields sent as %q", fields)
+	}
+
+	fields = ""
+	if _, err := client.Order.GetOrderDetailFields(shopID, []string{"SN123"}, accessToken, OrderFieldNote, "buyer_usrname"); err == nil {
+		t.Errorf("Order.GetOrderDetailFields accepted an unknown field")
+	}
+	if fields != "" {
+		t.Errorf("Order.GetOrderDetailFields called shopee with %q", fields)
+	}
+}
@@ -1,7 +1,12 @@
 package goshopee
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -25,4 +30,355 @@ func Test_GetOrderDetail(t *testing.T) {
 	if res.Response.OrderList[0].PackageList[0].PackageNumber != expected {
 		t.Errorf("PackageNumber returned %+v, expected %+v",res.Response.OrderList[0].PackageList[0].PackageNumber , expected)
 	}
-}
\ No newline at end of file
+}
+
+func Test_GetOrderList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_order_list_resp.json")))
+
+	req := GetOrderListRequest{
+		TimeRangeField:         OrderTimeRangeCreateTime,
+		TimeFrom:               1607235072,
+		TimeTo:                 1608271872,
+		PageSize:               20,
+		ResponseOptionalFields: "order_status",
+	}
+	res, err := client.Order.GetOrderList(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Order.GetOrderList error: %s", err)
+	}
+
+	t.Logf("Order.GetOrderList: %#v", res)
+
+	var expected string = "201214JASXYXY6"
+	if res.Response.OrderList[1].OrderSN != expected {
+		t.Errorf("OrderList[1].OrderSN returned %+v, expected %+v", res.Response.OrderList[1].OrderSN, expected)
+	}
+	if res.Response.NextCursor != "20" {
+		t.Errorf("NextCursor returned %+v, expected %+v", res.Response.NextCursor, "20")
+	}
+}
+
+func Test_GetShipmentList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_shipment_list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_shipment_list_resp.json")))
+
+	res, err := client.Order.GetShipmentList(shopID, GetShipmentListRequest{PageSize: 100}, accessToken)
+	if err != nil {
+		t.Errorf("Order.GetShipmentList error: %s", err)
+	}
+
+	t.Logf("Order.GetShipmentList: %#v", res)
+
+	var expected string = "2512345678"
+	if res.Response.OrderList[0].PackageNumber != expected {
+		t.Errorf("OrderList[0].PackageNumber returned %+v, expected %+v", res.Response.OrderList[0].PackageNumber, expected)
+	}
+}
+
+func Test_SyncOrders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const days = 40
+	var windows, batches int
+
+	// every window has 2 pages of 30 orders
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			from, _ := strconv.ParseInt(q.Get("time_from"), 10, 64)
+			to, _ := strconv.ParseInt(q.Get("time_to"), 10, 64)
+			if to-from > OrderListMaxTimeRange {
+				return httpmock.NewStringResponse(400, `{"error":"error_param","message":"time range too long"}`), nil
+			}
+
+			res := GetOrderListResponse{}
+			page := 0
+			if q.Get("cursor") == "" {
+				windows++
+				res.Response.More = true
+				res.Response.NextCursor = "30"
+			} else {
+				page = 1
+			}
+			for i := 0; i < 30; i++ {
+				sn := fmt.Sprintf("%d-%d-%d", from, page, i)
+				res.Response.OrderList = append(res.Response.OrderList, GetOrderListResponseDataItem{OrderSN: sn})
+			}
+			return httpmock.NewJsonResponse(200, res)
+		})
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_detail", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			batches++
+			snlist := strings.Split(req.URL.Query().Get("order_sn_list"), ",")
+			if len(snlist) > OrderDetailMaxSNList {
+				return httpmock.NewStringResponse(400, `{"error":"error_param","message":"too many order sn"}`), nil
+			}
+
+			res := GetOrderDetailResponse{}
+			for _, sn := range snlist {
+				res.Response.OrderList = append(res.Response.OrderList, Order{OrderSN: sn})
+			}
+			return httpmock.NewJsonResponse(200, res)
+		})
+
+	req := SyncOrdersRequest{
+		TimeRangeField: OrderTimeRangeUpdateTime,
+		TimeFrom:       1600000000,
+		TimeTo:         1600000000 + days*24*60*60,
+	}
+
+	seen := map[string]bool{}
+	err := client.Order.SyncOrders(context.Background(), shopID, req, accessToken, func(o Order) error {
+		if seen[o.OrderSN] {
+			t.Errorf("order %s synced twice", o.OrderSN)
+		}
+		seen[o.OrderSN] = true
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Order.SyncOrders error: %s", err)
+	}
+
+	if windows != 3 {
+		t.Errorf("SyncOrders listed %d windows, expected 3", windows)
+	}
+	if len(seen) != 180 {
+		t.Errorf("SyncOrders returned %d orders, expected 180", len(seen))
+	}
+	if batches != 4 {
+		t.Errorf("SyncOrders fetched %d detail batches, expected 4", batches)
+	}
+}
+
+func Test_SyncOrdersDuplicatesAndStuckCursor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	stuck := false
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			// SN1 is updated while syncing and shows up in every window
+			res := GetOrderListResponse{}
+			res.Response.OrderList = []GetOrderListResponseDataItem{{OrderSN: "SN1"}, {OrderSN: "SN" + req.URL.Query().Get("time_from")}}
+			if stuck {
+				res.Response.More = true
+				res.Response.NextCursor = req.URL.Query().Get("cursor")
+			}
+			return httpmock.NewJsonResponse(200, res)
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_detail", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			res := GetOrderDetailResponse{}
+			for _, sn := range strings.Split(req.URL.Query().Get("order_sn_list"), ",") {
+				res.Response.OrderList = append(res.Response.OrderList, Order{OrderSN: sn})
+			}
+			return httpmock.NewJsonResponse(200, res)
+		})
+
+	req := SyncOrdersRequest{TimeRangeField: OrderTimeRangeUpdateTime, TimeFrom: 1600000000, TimeTo: 1600000000 + 40*24*60*60}
+	var synced []string
+	err := client.Order.SyncOrders(context.Background(), shopID, req, accessToken, func(o Order) error {
+		synced = append(synced, o.OrderSN)
+		return nil
+	})
+	if err != nil || len(synced) != 4 {
+		t.Errorf("Order.SyncOrders synced %v, %v, expected SN1 once and 3 others", synced, err)
+	}
+
+	stuck = true
+	err = client.Order.SyncOrders(context.Background(), shopID, req, accessToken, func(o Order) error { return nil })
+	if !errors.Is(err, ErrPageNotAdvancing) {
+		t.Errorf("Order.SyncOrders with a stuck cursor returned %v", err)
+	}
+
+	calls := httpmock.GetTotalCallCount()
+	req.TimeRangeField = "pay_time"
+	if err := client.Order.SyncOrders(context.Background(), shopID, req, accessToken, func(o Order) error { return nil }); err == nil {
+		t.Errorf("Order.SyncOrders accepted time range field %q", req.TimeRangeField)
+	}
+	if httpmock.GetTotalCallCount() != calls {
+		t.Errorf("Order.SyncOrders called shopee with an invalid time range field")
+	}
+
+	req.TimeRangeField = OrderTimeRangeUpdateTime
+	req.TimeFrom, req.TimeTo = req.TimeTo, req.TimeFrom
+	if err := client.Order.SyncOrders(context.Background(), shopID, req, accessToken, func(o Order) error { return nil }); !errors.Is(err, ErrValidation) {
+		t.Errorf("Order.SyncOrders with time_from after time_to returned %v, expected ErrValidation", err)
+	}
+	if httpmock.GetTotalCallCount() != calls {
+		t.Errorf("Order.SyncOrders called shopee with time_from after time_to")
+	}
+}
+
+func Test_CancelOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/order/cancel_order", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("cancel_order_resp.json")))
+
+	var req CancelOrderRequest
+	loadMockData("cancel_order_req.json", &req)
+
+	res, err := client.Order.CancelOrder(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Order.CancelOrder error: %s", err)
+	}
+
+	t.Logf("Order.CancelOrder: %#v", res)
+
+	var expected int64 = 1608270984
+	if res.Response.UpdateTime != expected {
+		t.Errorf("UpdateTime returned %+v, expected %+v", res.Response.UpdateTime, expected)
+	}
+}
+
+func Test_HandleBuyerCancellation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/order/handle_buyer_cancellation", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("cancel_order_resp.json")))
+
+	req := HandleBuyerCancellationRequest{
+		OrderSN:   "201214JASXYXY6",
+		Operation: BuyerCancellationAccept,
+	}
+	res, err := client.Order.HandleBuyerCancellation(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Order.HandleBuyerCancellation error: %s", err)
+	}
+
+	t.Logf("Order.HandleBuyerCancellation: %#v", res)
+
+	var expected int64 = 1608270984
+	if res.Response.UpdateTime != expected {
+		t.Errorf("UpdateTime returned %+v, expected %+v", res.Response.UpdateTime, expected)
+	}
+}
+
+func Test_SetNote(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/order/set_note", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("response.json")))
+
+	res, err := client.Order.SetNote(shopID, "201214JASXYXY6", "fragile", accessToken)
+	if err != nil {
+		t.Errorf("Order.SetNote error: %s", err)
+	}
+
+	t.Logf("Order.SetNote: %#v", res)
+
+	var expected string = "f634ea27eff8461b8f6f9ffa1d7ddab2"
+	if res.RequestID != expected {
+		t.Errorf("RequestID returned %+v, expected %+v", res.RequestID, expected)
+	}
+}
+
+func Test_SplitOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/order/split_order", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("split_order_resp.json")))
+
+	var req SplitOrderRequest
+	loadMockData("split_order_req.json", &req)
+
+	res, err := client.Order.SplitOrder(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Order.SplitOrder error: %s", err)
+	}
+
+	t.Logf("Order.SplitOrder: %#v", res)
+
+	var expected string = "61630084074471"
+	if res.Response.PackageList[1].PackageNumber != expected {
+		t.Errorf("PackageList[1].PackageNumber returned %+v, expected %+v", res.Response.PackageList[1].PackageNumber, expected)
+	}
+}
+
+func Test_UnsplitOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/order/unsplit_order", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("response.json")))
+
+	res, err := client.Order.UnsplitOrder(shopID, "201214JASXYXY6", accessToken)
+	if err != nil {
+		t.Errorf("Order.UnsplitOrder error: %s", err)
+	}
+
+	t.Logf("Order.UnsplitOrder: %#v", res)
+
+	var expected string = "f634ea27eff8461b8f6f9ffa1d7ddab2"
+	if res.RequestID != expected {
+		t.Errorf("RequestID returned %+v, expected %+v", res.RequestID, expected)
+	}
+}
+
+func Test_GetPendingBuyerInvoiceOrderList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_pending_buyer_invoice_order_list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_pending_buyer_invoice_order_list_resp.json")))
+
+	req := GetPendingBuyerInvoiceOrderListRequest{PageSize: 10}
+	res, err := client.Order.GetPendingBuyerInvoiceOrderList(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Order.GetPendingBuyerInvoiceOrderList error: %s", err)
+	}
+
+	t.Logf("Order.GetPendingBuyerInvoiceOrderList: %#v", res)
+
+	var expected string = "2011300B5BNNP3"
+	if res.Response.OrderSNList[1] != expected {
+		t.Errorf("OrderSNList[1] returned %+v, expected %+v", res.Response.OrderSNList[1], expected)
+	}
+}
+
+func Test_UploadInvoiceDoc(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/order/upload_invoice_doc", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			if sn := req.FormValue("order_sn"); sn != "201214JASXYXY6" {
+				return httpmock.NewStringResponse(400, `{"error":"error_param","message":"wrong order_sn"}`), nil
+			}
+			if _, _, err := req.FormFile("file"); err != nil {
+				return httpmock.NewStringResponse(400, `{"error":"error_param","message":"no file"}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("response.json")), nil
+		})
+
+	req := UploadInvoiceDocRequest{
+		OrderSN:  "201214JASXYXY6",
+		FileType: InvoiceFileTypeJPEG,
+		Filename: "fixtures/test.jpg",
+	}
+	res, err := client.Order.UploadInvoiceDoc(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Order.UploadInvoiceDoc error: %s", err)
+	}
+
+	t.Logf("Order.UploadInvoiceDoc: %#v", res)
+
+	var expected string = "f634ea27eff8461b8f6f9ffa1d7ddab2"
+	if res.RequestID != expected {
+		t.Errorf("RequestID returned %+v, expected %+v", res.RequestID, expected)
+	}
+}
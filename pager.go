@@ -0,0 +1,278 @@
+package goshopee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrNoMorePages is returned by Pager.Next after the last page
+	ErrNoMorePages = errors.New("goshopee: no more pages")
+	// ErrPageNotAdvancing is returned when shopee reports more pages with a
+	// next page_no, offset or cursor that would fetch a page again
+	ErrPageNotAdvancing = errors.New("goshopee: next page token did not advance")
+)
+
+// PageToken locates a page. Endpoints paged by page_no use PageNo, the ones
+// paged by offset use Offset and cursor based ones use Cursor.
+type PageToken struct {
+	PageNo int
+	Offset int
+	Cursor string
+}
+
+// PageFunc fetches the page at tok. It returns the items of the page, the
+// token of the next page and whether there is a next page at all.
+// advances reports whether next is a page after t
+func (t PageToken) advances(next PageToken) bool {
+	return next != t && next.PageNo >= t.PageNo && next.Offset >= t.Offset
+}
+
+type PageFunc[T any] func(ctx context.Context, tok PageToken) (items []T, next PageToken, more bool, err error)
+
+type PagerOption func(*pagerOptions)
+
+type pagerOptions struct {
+	prefetch int
+}
+
+// PagerPrefetch lets the pager fetch up to n pages ahead of the caller in a
+// background goroutine. Pages are still returned in order.
+func PagerPrefetch(n int) PagerOption {
+	return func(o *pagerOptions) {
+		o.prefetch = n
+	}
+}
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// Pager walks the pages of a paged endpoint. It stops after the page that
+// reports no more pages, or at the first error, which Next keeps returning.
+//
+// A Pager is not safe for concurrent use. With PagerPrefetch, pages are
+// fetched with the context passed to the first Next call; call Stop when
+// abandoning the pager before its end.
+type Pager[T any] struct {
+	fetch    PageFunc[T]
+	tok      PageToken
+	done     bool
+	err      error
+	prefetch int
+
+	results  chan pageResult[T]
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewPager returns a pager calling fetch from the page at start
+func NewPager[T any](fetch PageFunc[T], start PageToken, opts ...PagerOption) *Pager[T] {
+	var o pagerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Pager[T]{
+		fetch:    fetch,
+		tok:      start,
+		prefetch: o.prefetch,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Next returns the items of the next page, or ErrNoMorePages after the last
+// one.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.done {
+		return nil, ErrNoMorePages
+	}
+
+	if p.prefetch > 0 {
+		return p.nextPrefetched(ctx)
+	}
+
+	items, next, more, err := p.fetch(ctx, p.tok)
+	if err == nil && more && !p.tok.advances(next) {
+		err = notAdvancing(p.tok, next)
+	}
+	if err != nil {
+		p.err = err
+		return nil, err
+	}
+	p.tok = next
+	p.done = !more
+	return items, nil
+}
+
+func (p *Pager[T]) nextPrefetched(ctx context.Context) ([]T, error) {
+	if p.results == nil {
+		p.results = make(chan pageResult[T], p.prefetch)
+		go p.run(ctx, p.tok)
+	}
+
+	select {
+	case r, ok := <-p.results:
+		if !ok {
+			p.done = true
+			return nil, ErrNoMorePages
+		}
+		if r.err != nil {
+			p.err = r.err
+			return nil, r.err
+		}
+		return r.items, nil
+	case <-ctx.Done():
+		p.err = ctx.Err()
+		return nil, p.err
+	}
+}
+
+func (p *Pager[T]) run(ctx context.Context, tok PageToken) {
+	defer close(p.results)
+	for {
+		items, next, more, err := p.fetch(ctx, tok)
+		if err == nil && more && !tok.advances(next) {
+			items, err = nil, notAdvancing(tok, next)
+		}
+		select {
+		case p.results <- pageResult[T]{items: items, err: err}:
+		case <-p.stop:
+			return
+		}
+		if err != nil || !more {
+			return
+		}
+		tok = next
+	}
+}
+
+func notAdvancing(tok, next PageToken) error {
+	return fmt.Errorf("%w: next page %+v after %+v", ErrPageNotAdvancing, next, tok)
+}
+
+// Stop ends prefetching. Next returns ErrNoMorePages once the pages already
+// fetched are consumed.
+func (p *Pager[T]) Stop() {
+	p.stopOnce.Do(func() { close(p.stop) })
+}
+
+// Each calls fn for every item of every page until fn returns an error.
+func (p *Pager[T]) Each(ctx context.Context, fn func(T) error) error {
+	defer p.Stop()
+	for {
+		items, err := p.Next(ctx)
+		if err == ErrNoMorePages {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, it := range items {
+			if err := fn(it); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// All returns the items of every page
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	err := p.Each(ctx, func(it T) error {
+		all = append(all, it)
+		return nil
+	})
+	return all, err
+}
+
+// NewBrandListPager walks get_brand_list by offset
+func NewBrandListPager(s ProductService, sid, cid uint64, status, pageSize int, tok string, opts ...PagerOption) *Pager[Brand] {
+	fetch := func(ctx context.Context, pt PageToken) ([]Brand, PageToken, bool, error) {
+		res, err := s.GetBrandListWithContext(ctx, sid, cid, status, pt.Offset, pageSize, tok)
+		if err != nil {
+			return nil, pt, false, err
+		}
+		return res.Response.BrandList, PageToken{Offset: res.Response.NextOffset}, res.Response.HasNextPage, nil
+	}
+	return NewPager(fetch, PageToken{}, opts...)
+}
+
+// NewItemListPager walks get_item_list by offset from opt.Offset
+func NewItemListPager(s ProductService, sid uint64, opt GetItemListRequest, tok string, opts ...PagerOption) *Pager[GetItemListResponseDataItem] {
+	fetch := func(ctx context.Context, pt PageToken) ([]GetItemListResponseDataItem, PageToken, bool, error) {
+		req := opt
+		req.Offset = pt.Offset
+		res, err := s.GetItemListWithContext(ctx, sid, req, tok)
+		if err != nil {
+			return nil, pt, false, err
+		}
+		return res.Response.Item, PageToken{Offset: res.Response.NextOffset}, res.Response.HasNextPage, nil
+	}
+	return NewPager(fetch, PageToken{Offset: opt.Offset}, opts...)
+}
+
+// NewShopListByMerchantPager walks get_shop_list_by_merchant by page_no,
+// starting at page 1
+func NewShopListByMerchantPager(s MerchantService, mid uint64, pageSize int, tok string, opts ...PagerOption) *Pager[GetShopListByMerchantResponseData] {
+	fetch := func(ctx context.Context, pt PageToken) ([]GetShopListByMerchantResponseData, PageToken, bool, error) {
+		res, err := s.GetShopListByMerchantWithContext(ctx, mid, pt.PageNo, pageSize, tok)
+		if err != nil {
+			return nil, pt, false, err
+		}
+		return res.ShopList, PageToken{PageNo: pt.PageNo + 1}, res.More, nil
+	}
+	return NewPager(fetch, PageToken{PageNo: 1}, opts...)
+}
+
+// NewDiscountListPager walks get_discount_list by page_no from opt.PageNo
+func NewDiscountListPager(s DiscountService, sid uint64, opt GetDiscountListRequest, tok string, opts ...PagerOption) *Pager[GetDiscountListResponseDataDiscount] {
+	fetch := func(ctx context.Context, pt PageToken) ([]GetDiscountListResponseDataDiscount, PageToken, bool, error) {
+		req := opt
+		req.PageNo = pt.PageNo
+		res, err := s.GetDiscountListWithContext(ctx, sid, req, tok)
+		if err != nil {
+			return nil, pt, false, err
+		}
+		return res.Response.DiscountList, PageToken{PageNo: pt.PageNo + 1}, res.Response.More, nil
+	}
+	return NewPager(fetch, PageToken{PageNo: opt.PageNo}, opts...)
+}
+
+// NewDiscountItemPager walks the items of a discount by page_no from
+// opt.PageNo
+func NewDiscountItemPager(s DiscountService, sid uint64, opt GetDiscountRequest, tok string, opts ...PagerOption) *Pager[GetDiscountResponseDataItem] {
+	fetch := func(ctx context.Context, pt PageToken) ([]GetDiscountResponseDataItem, PageToken, bool, error) {
+		req := opt
+		req.PageNo = pt.PageNo
+		res, err := s.GetDiscountWithContext(ctx, sid, req, tok)
+		if err != nil {
+			return nil, pt, false, err
+		}
+		return res.Response.ItemList, PageToken{PageNo: pt.PageNo + 1}, res.Response.More, nil
+	}
+	return NewPager(fetch, PageToken{PageNo: opt.PageNo}, opts...)
+}
+
+// NewOrderListPager walks get_order_list by cursor from opt.Cursor
+func NewOrderListPager(s OrderService, sid uint64, opt GetOrderListRequest, tok string, opts ...PagerOption) *Pager[GetOrderListResponseDataItem] {
+	fetch := func(ctx context.Context, pt PageToken) ([]GetOrderListResponseDataItem, PageToken, bool, error) {
+		req := opt
+		req.Cursor = pt.Cursor
+		res, err := s.GetOrderListWithContext(ctx, sid, req, tok)
+		if err != nil {
+			return nil, pt, false, err
+		}
+		if res.Response.More && res.Response.NextCursor == "" {
+			// an empty cursor would start over from the first page
+			return nil, pt, false, notAdvancing(pt, PageToken{})
+		}
+		return res.Response.OrderList, PageToken{Cursor: res.Response.NextCursor}, res.Response.More, nil
+	}
+	return NewPager(fetch, PageToken{Cursor: opt.Cursor}, opts...)
+}
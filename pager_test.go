@@ -0,0 +1,162 @@
+package goshopee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_BrandListPager(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_brand_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+			body := fmt.Sprintf(`{"response":{"brand_list":[{"brand_id":%d},{"brand_id":%d}],"has_next_page":%t,"next_offset":%d}}`,
+				offset+1, offset+2, offset < 4, offset+2)
+			return httpmock.NewStringResponse(200, body), nil
+		})
+
+	brands, err := NewBrandListPager(client.Product, shopID, 100, 1, 2, accessToken).All(context.Background())
+	if err != nil {
+		t.Fatalf("BrandListPager error: %s", err)
+	}
+	if len(brands) != 6 || brands[5].BrandID != 6 {
+		t.Errorf("BrandListPager returned %+v, expected brands 1 to 6", brands)
+	}
+}
+
+func Test_ShopListByMerchantPager(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var pages []string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/merchant/get_shop_list_by_merchant", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			pageNo := req.URL.Query().Get("page_no")
+			pages = append(pages, pageNo)
+			body := fmt.Sprintf(`{"shop_list":[{"shop_id":%s}],"more":%t}`, pageNo, pageNo != "3")
+			return httpmock.NewStringResponse(200, body), nil
+		})
+
+	p := NewShopListByMerchantPager(client.Merchant, merchantID, 1, accessToken)
+	for i := 1; ; i++ {
+		shops, err := p.Next(context.Background())
+		if err == ErrNoMorePages {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ShopListByMerchantPager error: %s", err)
+		}
+		if shops[0].ShopID != uint64(i) {
+			t.Errorf("page %d returned shop %d", i, shops[0].ShopID)
+		}
+	}
+	if fmt.Sprint(pages) != "[1 2 3]" {
+		t.Errorf("ShopListByMerchantPager requested pages %v, expected [1 2 3]", pages)
+	}
+}
+
+func Test_OrderListPagerPrefetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			n, _ := strconv.Atoi(req.URL.Query().Get("cursor"))
+			body := fmt.Sprintf(`{"response":{"order_list":[{"order_sn":"SN%d"}],"more":%t,"next_cursor":"%d"}}`, n, n < 9, n+1)
+			return httpmock.NewStringResponse(200, body), nil
+		})
+
+	req := GetOrderListRequest{
+		TimeRangeField: OrderTimeRangeCreateTime,
+		TimeFrom:       1607235072,
+		TimeTo:         1608271872,
+		PageSize:       1,
+	}
+	var sns []string
+	err := NewOrderListPager(client.Order, shopID, req, accessToken, PagerPrefetch(2)).Each(context.Background(),
+		func(o GetOrderListResponseDataItem) error {
+			sns = append(sns, o.OrderSN)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("OrderListPager error: %s", err)
+	}
+	if len(sns) != 10 || sns[0] != "SN0" || sns[9] != "SN9" {
+		t.Errorf("OrderListPager returned %v, expected SN0 to SN9", sns)
+	}
+}
+
+func Test_PagerStopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	fetch := func(ctx context.Context, pt PageToken) ([]int, PageToken, bool, error) {
+		calls++
+		if pt.PageNo == 2 {
+			return nil, pt, false, boom
+		}
+		return []int{pt.PageNo}, PageToken{PageNo: pt.PageNo + 1}, true, nil
+	}
+
+	for _, opts := range [][]PagerOption{nil, {PagerPrefetch(3)}} {
+		calls = 0
+		p := NewPager(fetch, PageToken{PageNo: 1}, opts...)
+		items, err := p.All(context.Background())
+		if err != boom {
+			t.Errorf("Pager.All returned error %v, expected %v", err, boom)
+		}
+		if len(items) != 1 {
+			t.Errorf("Pager.All returned %v, expected [1]", items)
+		}
+		if _, err := p.Next(context.Background()); err != boom {
+			t.Errorf("Pager.Next after error returned %v, expected %v", err, boom)
+		}
+		if calls != 2 {
+			t.Errorf("fetch called %d times, expected 2", calls)
+		}
+	}
+}
+
+func Test_PagerNotAdvancing(t *testing.T) {
+	fetch := func(ctx context.Context, pt PageToken) ([]int, PageToken, bool, error) {
+		return []int{pt.Offset}, PageToken{Offset: 10}, true, nil
+	}
+
+	for _, opts := range [][]PagerOption{nil, {PagerPrefetch(3)}} {
+		p := NewPager(fetch, PageToken{}, opts...)
+		items, err := p.All(context.Background())
+		if !errors.Is(err, ErrPageNotAdvancing) {
+			t.Errorf("Pager.All returned error %v, expected ErrPageNotAdvancing", err)
+		}
+		if fmt.Sprint(items) != "[0]" {
+			t.Errorf("Pager.All returned %v, expected [0]", items)
+		}
+	}
+}
+
+func Test_PagerPrefetchCancelled(t *testing.T) {
+	release := make(chan struct{})
+	fetch := func(ctx context.Context, pt PageToken) ([]int, PageToken, bool, error) {
+		<-release
+		return []int{pt.PageNo}, PageToken{PageNo: pt.PageNo + 1}, true, nil
+	}
+	p := NewPager(fetch, PageToken{PageNo: 1}, PagerPrefetch(1))
+	defer close(release)
+	defer p.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Next(ctx); err != context.Canceled {
+		t.Fatalf("Pager.Next returned %v, expected context.Canceled", err)
+	}
+	if _, err := p.Next(context.Background()); err != context.Canceled {
+		t.Errorf("Pager.Next after cancellation returned %v, expected context.Canceled", err)
+	}
+}
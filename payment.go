@@ -0,0 +1,345 @@
+package goshopee
+
+import "context"
+
+// https://open.shopee.com/documents/v2/v2.payment.get_escrow_detail?module=97&type=1
+type PaymentService interface {
+	GetEscrowDetail(uint64, string, string) (*GetEscrowDetailResponse, error)
+	GetEscrowDetailWithContext(context.Context, uint64, string, string) (*GetEscrowDetailResponse, error)
+	GetEscrowList(uint64, GetEscrowListRequest, string) (*GetEscrowListResponse, error)
+	GetEscrowListWithContext(context.Context, uint64, GetEscrowListRequest, string) (*GetEscrowListResponse, error)
+	GetPayoutDetail(uint64, GetPayoutDetailRequest, string) (*GetPayoutDetailResponse, error)
+	GetPayoutDetailWithContext(context.Context, uint64, GetPayoutDetailRequest, string) (*GetPayoutDetailResponse, error)
+	GetWalletTransactionList(uint64, GetWalletTransactionListRequest, string) (*GetWalletTransactionListResponse, error)
+	GetWalletTransactionListWithContext(context.Context, uint64, GetWalletTransactionListRequest, string) (*GetWalletTransactionListResponse, error)
+	GetIncomeDetail(uint64, GetIncomeDetailRequest, string) (*GetIncomeDetailResponse, error)
+	GetIncomeDetailWithContext(context.Context, uint64, GetIncomeDetailRequest, string) (*GetIncomeDetailResponse, error)
+}
+
+type PaymentServiceOp struct {
+	client *Client
+}
+
+type GetEscrowDetailRequest struct {
+	OrderSN string `url:"order_sn"`
+}
+
+type GetEscrowDetailResponse struct {
+	BaseResponse
+
+	Response EscrowDetail `json:"response"`
+}
+
+// Amounts are in the currency of the order, see Order.Currency
+type EscrowDetail struct {
+	OrderSN           string            `json:"order_sn"`
+	BuyerUserName     string            `json:"buyer_user_name"`
+	ReturnOrderSNList []string          `json:"return_order_sn_list"`
+	OrderIncome       EscrowOrderIncome `json:"order_income"`
+	BuyerPaymentInfo  EscrowBuyerInfo   `json:"buyer_payment_info"`
+}
+
+// EscrowOrderIncome is the settlement breakdown of an order. EscrowAmount is
+// what the seller finally receives.
+type EscrowOrderIncome struct {
+	EscrowAmount                float64      `json:"escrow_amount"`
+	BuyerTotalAmount            float64      `json:"buyer_total_amount"`
+	OriginalPrice               float64      `json:"original_price"`
+	SellerDiscount              float64      `json:"seller_discount"`
+	ShopeeDiscount              float64      `json:"shopee_discount"`
+	VoucherFromSeller           float64      `json:"voucher_from_seller"`
+	VoucherFromShopee           float64      `json:"voucher_from_shopee"`
+	Coins                       float64      `json:"coins"`
+	BuyerPaidShippingFee        float64      `json:"buyer_paid_shipping_fee"`
+	BuyerTransactionFee         float64      `json:"buyer_transaction_fee"`
+	CrossBorderTax              float64      `json:"cross_border_tax"`
+	PaymentPromotion            float64      `json:"payment_promotion"`
+	CommissionFee               float64      `json:"commission_fee"`
+	ServiceFee                  float64      `json:"service_fee"`
+	SellerTransactionFee        float64      `json:"seller_transaction_fee"`
+	SellerLostCompensation      float64      `json:"seller_lost_compensation"`
+	SellerCoinCashBack          float64      `json:"seller_coin_cash_back"`
+	EscrowTax                   float64      `json:"escrow_tax"`
+	FinalShippingFee            float64      `json:"final_shipping_fee"`
+	ActualShippingFee           float64      `json:"actual_shipping_fee"`
+	EstimatedShippingFee        float64      `json:"estimated_shipping_fee"`
+	ShopeeShippingRebate        float64      `json:"shopee_shipping_rebate"`
+	ShippingFeeDiscountFrom3PL  float64      `json:"shipping_fee_discount_from_3pl"`
+	SellerShippingDiscount      float64      `json:"seller_shipping_discount"`
+	SellerVoucherCode           []string     `json:"seller_voucher_code"`
+	DRCAdjustableRefund         float64      `json:"drc_adjustable_refund"`
+	CostOfGoodsSold             float64      `json:"cost_of_goods_sold"`
+	OriginalCostOfGoodsSold     float64      `json:"original_cost_of_goods_sold"`
+	OriginalShopeeDiscount      float64      `json:"original_shopee_discount"`
+	SellerReturnRefund          float64      `json:"seller_return_refund"`
+	EscrowAmountAfterAdjustment float64      `json:"escrow_amount_after_adjustment"`
+	Items                       []EscrowItem `json:"items"`
+}
+
+// EscrowItem is one line of an order. ItemID and ModelID match the
+// OrderItem of get_order_detail.
+type EscrowItem struct {
+	ItemID                    uint64  `json:"item_id"`
+	ItemName                  string  `json:"item_name"`
+	ItemSKU                   string  `json:"item_sku"`
+	ModelID                   uint64  `json:"model_id"`
+	ModelName                 string  `json:"model_name"`
+	ModelSKU                  string  `json:"model_sku"`
+	OriginalPrice             float64 `json:"original_price"`
+	DiscountedPrice           float64 `json:"discounted_price"`
+	SellerDiscount            float64 `json:"seller_discount"`
+	ShopeeDiscount            float64 `json:"shopee_discount"`
+	DiscountFromCoin          float64 `json:"discount_from_coin"`
+	DiscountFromVoucherShopee float64 `json:"discount_from_voucher_shopee"`
+	DiscountFromVoucherSeller float64 `json:"discount_from_voucher_seller"`
+	ActivityType              string  `json:"activity_type"`
+	ActivityID                uint64  `json:"activity_id"`
+	IsMainItem                bool    `json:"is_main_item"`
+	QuantityPurchased         int     `json:"quantity_purchased"`
+}
+
+type EscrowBuyerInfo struct {
+	BuyerPaymentMethod   string  `json:"buyer_payment_method"`
+	BuyerServiceFee      float64 `json:"buyer_service_fee"`
+	BuyerTaxAmount       float64 `json:"buyer_tax_amount"`
+	BuyerTotalAmount     float64 `json:"buyer_total_amount"`
+	CreditCardPromotion  float64 `json:"credit_card_promotion"`
+	IcmsTaxAmount        float64 `json:"icms_tax_amount"`
+	ImportTaxAmount      float64 `json:"import_tax_amount"`
+	InitialBuyerTxnFee   float64 `json:"initial_buyer_txn_fee"`
+	InsurancePremium     float64 `json:"insurance_premium"`
+	IOFTaxAmount         float64 `json:"iof_tax_amount"`
+	IsPaidByCreditCard   bool    `json:"is_paid_by_credit_card"`
+	MerchantSubtotal     float64 `json:"merchant_subtotal"`
+	SellerVoucher        float64 `json:"seller_voucher"`
+	ShippingFee          float64 `json:"shipping_fee"`
+	ShippingFeeSstAmount float64 `json:"shipping_fee_sst_amount"`
+	ShopeeVoucher        float64 `json:"shopee_voucher"`
+	ShopeeCoinsRedeemed  float64 `json:"shopee_coins_redeemed"`
+}
+
+// Item returns the escrow line of the order item itemID/modelID, so escrow
+// can be joined with Order.ItemList.
+func (o *EscrowOrderIncome) Item(itemID, modelID uint64) (EscrowItem, bool) {
+	for _, it := range o.Items {
+		if it.ItemID == itemID && it.ModelID == modelID {
+			return it, true
+		}
+	}
+	return EscrowItem{}, false
+}
+
+func (s *PaymentServiceOp) GetEscrowDetail(sid uint64, ordersn, tok string) (*GetEscrowDetailResponse, error) {
+	return s.GetEscrowDetailWithContext(context.Background(), sid, ordersn, tok)
+}
+
+func (s *PaymentServiceOp) GetEscrowDetailWithContext(ctx context.Context, sid uint64, ordersn, tok string) (*GetEscrowDetailResponse, error) {
+	path := "/payment/get_escrow_detail"
+
+	opt := GetEscrowDetailRequest{
+		OrderSN: ordersn,
+	}
+
+	resp := new(GetEscrowDetailResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.payment.get_escrow_list?module=97&type=1
+type GetEscrowListRequest struct {
+	ReleaseTimeFrom int64 `url:"release_time_from"`
+	ReleaseTimeTo   int64 `url:"release_time_to"`
+	PageSize        int   `url:"page_size"`
+	PageNo          int   `url:"page_no"`
+}
+
+type GetEscrowListResponse struct {
+	BaseResponse
+
+	Response GetEscrowListResponseData `json:"response"`
+}
+
+type GetEscrowListResponseData struct {
+	EscrowList []EscrowListItem `json:"escrow_list"`
+	More       bool             `json:"more"`
+}
+
+type EscrowListItem struct {
+	OrderSN           string  `json:"order_sn"`
+	PayoutAmount      float64 `json:"payout_amount"`
+	EscrowReleaseTime int64   `json:"escrow_release_time"`
+}
+
+func (s *PaymentServiceOp) GetEscrowList(sid uint64, opt GetEscrowListRequest, tok string) (*GetEscrowListResponse, error) {
+	return s.GetEscrowListWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *PaymentServiceOp) GetEscrowListWithContext(ctx context.Context, sid uint64, opt GetEscrowListRequest, tok string) (*GetEscrowListResponse, error) {
+	path := "/payment/get_escrow_list"
+
+	resp := new(GetEscrowListResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.payment.get_payout_detail?module=97&type=1
+type GetPayoutDetailRequest struct {
+	PayoutTimeFrom int64 `url:"payout_time_from"`
+	PayoutTimeTo   int64 `url:"payout_time_to"`
+	PageSize       int   `url:"page_size"`
+	PageNo         int   `url:"page_no"`
+}
+
+type GetPayoutDetailResponse struct {
+	BaseResponse
+
+	Response GetPayoutDetailResponseData `json:"response"`
+}
+
+type GetPayoutDetailResponseData struct {
+	PayoutList []Payout `json:"payout_list"`
+	More       bool     `json:"more"`
+}
+
+type Payout struct {
+	PayoutInfo            PayoutInfo                `json:"payout_info"`
+	EscrowList            []PayoutEscrow            `json:"escrow_list"`
+	OfflineAdjustmentList []PayoutOfflineAdjustment `json:"offline_adjustment_list"`
+}
+
+// PayoutInfo converts FromAmount in FromCurrency into PayoutAmount in
+// PayoutCurrency at ExchangeRate
+type PayoutInfo struct {
+	FromCurrency   string  `json:"from_currency"`
+	PayoutCurrency string  `json:"payout_currency"`
+	FromAmount     float64 `json:"from_amount"`
+	PayoutAmount   float64 `json:"payout_amount"`
+	ExchangeRate   string  `json:"exchange_rate"`
+	PayoutTime     int64   `json:"payout_time"`
+	PayService     string  `json:"pay_service"`
+	PayeeID        string  `json:"payee_id"`
+}
+
+type PayoutEscrow struct {
+	EscrowAmount float64 `json:"escrow_amount"`
+	Currency     string  `json:"currency"`
+	OrderSN      string  `json:"order_sn"`
+}
+
+type PayoutOfflineAdjustment struct {
+	AdjustmentAmount float64 `json:"adjustment_amount"`
+	Module           string  `json:"module"`
+	Remark           string  `json:"remark"`
+	AdjustmentTime   int64   `json:"adjustment_time"`
+}
+
+func (s *PaymentServiceOp) GetPayoutDetail(sid uint64, opt GetPayoutDetailRequest, tok string) (*GetPayoutDetailResponse, error) {
+	return s.GetPayoutDetailWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *PaymentServiceOp) GetPayoutDetailWithContext(ctx context.Context, sid uint64, opt GetPayoutDetailRequest, tok string) (*GetPayoutDetailResponse, error) {
+	path := "/payment/get_payout_detail"
+
+	resp := new(GetPayoutDetailResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.payment.get_wallet_transaction_list?module=97&type=1
+type GetWalletTransactionListRequest struct {
+	PageNo          int    `url:"page_no"`
+	PageSize        int    `url:"page_size"`
+	CreateTimeFrom  int64  `url:"create_time_from,omitempty"`
+	CreateTimeTo    int64  `url:"create_time_to,omitempty"`
+	WalletType      string `url:"wallet_type,omitempty"`
+	TransactionType string `url:"transaction_type,omitempty"`
+}
+
+type GetWalletTransactionListResponse struct {
+	BaseResponse
+
+	Response GetWalletTransactionListResponseData `json:"response"`
+}
+
+type GetWalletTransactionListResponseData struct {
+	TransactionList []WalletTransaction `json:"transaction_list"`
+	More            bool                `json:"more"`
+}
+
+type WalletTransaction struct {
+	TransactionID   uint64  `json:"transaction_id"`
+	Status          string  `json:"status"`
+	TransactionType string  `json:"transaction_type"`
+	WalletType      string  `json:"wallet_type"`
+	Amount          float64 `json:"amount"`
+	CurrentBalance  float64 `json:"current_balance"`
+	TransactionFee  float64 `json:"transaction_fee"`
+	CreateTime      int64   `json:"create_time"`
+	OrderSN         string  `json:"order_sn"`
+	RefundSN        string  `json:"refund_sn"`
+	WithdrawalType  string  `json:"withdrawal_type"`
+	WithdrawalID    uint64  `json:"withdrawal_id"`
+	Description     string  `json:"description"`
+	BuyerName       string  `json:"buyer_name"`
+	Reason          string  `json:"reason"`
+}
+
+func (s *PaymentServiceOp) GetWalletTransactionList(sid uint64, opt GetWalletTransactionListRequest, tok string) (*GetWalletTransactionListResponse, error) {
+	return s.GetWalletTransactionListWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *PaymentServiceOp) GetWalletTransactionListWithContext(ctx context.Context, sid uint64, opt GetWalletTransactionListRequest, tok string) (*GetWalletTransactionListResponse, error) {
+	path := "/payment/get_wallet_transaction_list"
+
+	resp := new(GetWalletTransactionListResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.payment.get_income_detail?module=97&type=1
+const (
+	IncomeStatusToRelease = 0
+	IncomeStatusReleased  = 1
+)
+
+type GetIncomeDetailRequest struct {
+	DateFrom     string `url:"date_from"` // YYYY-MM-DD
+	DateTo       string `url:"date_to"`
+	IncomeStatus int    `url:"income_status"`
+	PageSize     int    `url:"page_size"`
+	Cursor       string `url:"cursor,omitempty"`
+}
+
+type GetIncomeDetailResponse struct {
+	BaseResponse
+
+	Response GetIncomeDetailResponseData `json:"response"`
+}
+
+type GetIncomeDetailResponseData struct {
+	IncomeList []Income `json:"income_list"`
+	More       bool     `json:"more"`
+	NextCursor string   `json:"next_cursor"`
+}
+
+type Income struct {
+	OrderSN               string  `json:"order_sn"`
+	Status                string  `json:"status"`
+	Currency              string  `json:"currency"`
+	Amount                float64 `json:"amount"`
+	EstimatedEscrowAmount float64 `json:"estimated_escrow_amount"`
+	EscrowReleaseTime     int64   `json:"escrow_release_time"`
+	OrderCompleteTime     int64   `json:"order_complete_time"`
+}
+
+func (s *PaymentServiceOp) GetIncomeDetail(sid uint64, opt GetIncomeDetailRequest, tok string) (*GetIncomeDetailResponse, error) {
+	return s.GetIncomeDetailWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *PaymentServiceOp) GetIncomeDetailWithContext(ctx context.Context, sid uint64, opt GetIncomeDetailRequest, tok string) (*GetIncomeDetailResponse, error) {
+	path := "/payment/get_income_detail"
+
+	resp := new(GetIncomeDetailResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
@@ -0,0 +1,147 @@
+package goshopee
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_GetEscrowDetail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/payment/get_escrow_detail", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_escrow_detail_resp.json")))
+
+	res, err := client.Payment.GetEscrowDetail(shopID, "201218V2Y6E59M", accessToken)
+	if err != nil {
+		t.Errorf("Payment.GetEscrowDetail error: %s", err)
+	}
+
+	t.Logf("Payment.GetEscrowDetail: %#v", res)
+
+	var expected float64 = 18.34
+	if res.Response.OrderIncome.EscrowAmount != expected {
+		t.Errorf("OrderIncome.EscrowAmount returned %+v, expected %+v", res.Response.OrderIncome.EscrowAmount, expected)
+	}
+
+	item, ok := res.Response.OrderIncome.Item(100917326, 10000569432)
+	if !ok {
+		t.Fatalf("OrderIncome.Item not found")
+	}
+	if item.DiscountedPrice != 22.9 {
+		t.Errorf("EscrowItem.DiscountedPrice returned %+v, expected %+v", item.DiscountedPrice, 22.9)
+	}
+	if _, ok := res.Response.OrderIncome.Item(100917326, 1); ok {
+		t.Errorf("OrderIncome.Item found unknown model")
+	}
+}
+
+func Test_GetEscrowList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/payment/get_escrow_list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_escrow_list_resp.json")))
+
+	req := GetEscrowListRequest{
+		ReleaseTimeFrom: 1609000000,
+		ReleaseTimeTo:   1609200000,
+		PageSize:        40,
+		PageNo:          1,
+	}
+	res, err := client.Payment.GetEscrowList(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Payment.GetEscrowList error: %s", err)
+	}
+
+	t.Logf("Payment.GetEscrowList: %#v", res)
+
+	var expected string = "201218V2Y6E59M"
+	if res.Response.EscrowList[0].OrderSN != expected {
+		t.Errorf("EscrowList[0].OrderSN returned %+v, expected %+v", res.Response.EscrowList[0].OrderSN, expected)
+	}
+}
+
+func Test_GetPayoutDetail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/payment/get_payout_detail", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_payout_detail_resp.json")))
+
+	req := GetPayoutDetailRequest{
+		PayoutTimeFrom: 1609000000,
+		PayoutTimeTo:   1609300000,
+		PageSize:       40,
+		PageNo:         1,
+	}
+	res, err := client.Payment.GetPayoutDetail(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Payment.GetPayoutDetail error: %s", err)
+	}
+
+	t.Logf("Payment.GetPayoutDetail: %#v", res)
+
+	info := res.Response.PayoutList[0].PayoutInfo
+	if info.PayoutCurrency != "USD" || info.PayoutAmount != 13.61 {
+		t.Errorf("PayoutInfo returned %s %+v, expected USD 13.61", info.PayoutCurrency, info.PayoutAmount)
+	}
+	if res.Response.PayoutList[0].EscrowList[0].Currency != "SGD" {
+		t.Errorf("EscrowList[0].Currency returned %+v, expected SGD", res.Response.PayoutList[0].EscrowList[0].Currency)
+	}
+}
+
+func Test_GetWalletTransactionList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/payment/get_wallet_transaction_list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_wallet_transaction_list_resp.json")))
+
+	req := GetWalletTransactionListRequest{
+		PageNo:   0,
+		PageSize: 40,
+	}
+	res, err := client.Payment.GetWalletTransactionList(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Payment.GetWalletTransactionList error: %s", err)
+	}
+
+	t.Logf("Payment.GetWalletTransactionList: %#v", res)
+
+	var expected float64 = 118.34
+	if res.Response.TransactionList[0].CurrentBalance != expected {
+		t.Errorf("TransactionList[0].CurrentBalance returned %+v, expected %+v", res.Response.TransactionList[0].CurrentBalance, expected)
+	}
+}
+
+func Test_GetIncomeDetail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/payment/get_income_detail", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_income_detail_resp.json")))
+
+	req := GetIncomeDetailRequest{
+		DateFrom:     "2020-12-01",
+		DateTo:       "2020-12-31",
+		IncomeStatus: IncomeStatusReleased,
+		PageSize:     50,
+	}
+	res, err := client.Payment.GetIncomeDetail(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Payment.GetIncomeDetail error: %s", err)
+	}
+
+	t.Logf("Payment.GetIncomeDetail: %#v", res)
+
+	var expected string = "1609141745_201218V2Y6E59M"
+	if res.Response.NextCursor != expected {
+		t.Errorf("NextCursor returned %+v, expected %+v", res.Response.NextCursor, expected)
+	}
+	if res.Response.IncomeList[0].Currency != "SGD" {
+		t.Errorf("IncomeList[0].Currency returned %+v, expected SGD", res.Response.IncomeList[0].Currency)
+	}
+}
@@ -1,27 +1,66 @@
 package goshopee
 
+import (
+	"context"
+	"errors"
+)
+
 type ProductService interface {
 	GetCategory(uint64, string, string) (*GetCategoryResponse, error)
+	GetCategoryWithContext(context.Context, uint64, string, string) (*GetCategoryResponse, error)
 	GetBrandList(uint64, uint64, int, int, int, string) (*GetBrandListResponse, error)
+	GetBrandListWithContext(context.Context, uint64, uint64, int, int, int, string) (*GetBrandListResponse, error)
 	GetDTSLimit(uint64, uint64, string) (*GetDTSLimitResponse, error)
+	GetDTSLimitWithContext(context.Context, uint64, uint64, string) (*GetDTSLimitResponse, error)
 	GetAttributes(uint64, uint64, string, string) (*GetAttributesResponse, error)
+	GetAttributesWithContext(context.Context, uint64, uint64, string, string) (*GetAttributesResponse, error)
 	SupportSizeChart(uint64, uint64, string) (*SupportSizeChartResponse, error)
+	SupportSizeChartWithContext(context.Context, uint64, uint64, string) (*SupportSizeChartResponse, error)
 	UpdateSizeChart(uint64, uint64, string, string) (*UpdateSizeChartResponse, error)
+	UpdateSizeChartWithContext(context.Context, uint64, uint64, string, string) (*UpdateSizeChartResponse, error)
 	GetItemBaseInfo(uint64, []uint64, string) (*GetItemBaseInfoResponse, error)
+	GetItemBaseInfoWithContext(context.Context, uint64, []uint64, string) (*GetItemBaseInfoResponse, error)
+	GetItemList(uint64, GetItemListRequest, string) (*GetItemListResponse, error)
+	GetItemListWithContext(context.Context, uint64, GetItemListRequest, string) (*GetItemListResponse, error)
+	ExportCatalog(context.Context, uint64, ExportCatalogRequest, string, func(CatalogItem) error) error
 	AddItem(uint64, AddItemRequest, string) (*AddItemResponse, error)
+	AddItemWithContext(context.Context, uint64, AddItemRequest, string) (*AddItemResponse, error)
 	DeleteItem(uint64, uint64, string) (*BaseResponse, error)
+	DeleteItemWithContext(context.Context, uint64, uint64, string) (*BaseResponse, error)
 	UpdateItem(uint64, UpdateItemRequest, string) (*UpdateItemResponse, error)
+	UpdateItemWithContext(context.Context, uint64, UpdateItemRequest, string) (*UpdateItemResponse, error)
 	UnlistItem(uint64, UnlistItemRequest, string) (*UnlistItemResponse, error)
+	UnlistItemWithContext(context.Context, uint64, UnlistItemRequest, string) (*UnlistItemResponse, error)
 	InitTierVariation(uint64, InitTierVariationRequest, string) (*InitTierVariationResponse, error)
+	InitTierVariationWithContext(context.Context, uint64, InitTierVariationRequest, string) (*InitTierVariationResponse, error)
 	UpdateTierVariation(uint64, UpdateTierVariationRequest, string) (*UpdateTierVariationResponse, error)
+	UpdateTierVariationWithContext(context.Context, uint64, UpdateTierVariationRequest, string) (*UpdateTierVariationResponse, error)
 	GetModelList(uint64, uint64, string) (*GetModelListResponse, error)
+	GetModelListWithContext(context.Context, uint64, uint64, string) (*GetModelListResponse, error)
 	AddModel(uint64, AddModelRequest, string) (*AddModelResponse, error)
+	AddModelWithContext(context.Context, uint64, AddModelRequest, string) (*AddModelResponse, error)
 	DeleteModel(uint64, uint64, uint64, string) (*BaseResponse, error)
+	DeleteModelWithContext(context.Context, uint64, uint64, uint64, string) (*BaseResponse, error)
 	UpdateModel(uint64, UpdateModelRequest, string) (*UpdateModelResponse, error)
+	UpdateModelWithContext(context.Context, uint64, UpdateModelRequest, string) (*UpdateModelResponse, error)
 	UpdatePrice(uint64, UpdatePriceRequest, string) (*UpdatePriceResponse, error)
+	UpdatePriceWithContext(context.Context, uint64, UpdatePriceRequest, string) (*UpdatePriceResponse, error)
 	UpdateStock(uint64, UpdateStockRequest, string) (*UpdateStockResponse, error)
+	UpdateStockWithContext(context.Context, uint64, UpdateStockRequest, string) (*UpdateStockResponse, error)
 	CategoryRecommend(uint64, string, string) (*CategoryRecommendResponse, error)
+	CategoryRecommendWithContext(context.Context, uint64, string, string) (*CategoryRecommendResponse, error)
 	GetItemPromotion(uint64, []uint64, string) (*GetItemPromotionResponse, error)
+	GetItemPromotionWithContext(context.Context, uint64, []uint64, string) (*GetItemPromotionResponse, error)
+	GetItemExtraInfo(uint64, []uint64, string) (*GetItemExtraInfoResponse, error)
+	GetItemExtraInfoWithContext(context.Context, uint64, []uint64, string) (*GetItemExtraInfoResponse, error)
+	SearchItem(uint64, SearchItemRequest, string) (*SearchItemResponse, error)
+	SearchItemWithContext(context.Context, uint64, SearchItemRequest, string) (*SearchItemResponse, error)
+	BoostItem(uint64, []uint64, string) (*BoostItemResponse, error)
+	BoostItemWithContext(context.Context, uint64, []uint64, string) (*BoostItemResponse, error)
+	GetBoostedList(uint64, string) (*GetBoostedListResponse, error)
+	GetBoostedListWithContext(context.Context, uint64, string) (*GetBoostedListResponse, error)
+	GetItemLimit(uint64, uint64, string) (*GetItemLimitResponse, error)
+	GetItemLimitWithContext(context.Context, uint64, uint64, string) (*GetItemLimitResponse, error)
 }
 
 type GetCategoryResponse struct {
@@ -51,6 +90,10 @@ type GetCategoryRequest struct {
 }
 
 func (s *ProductServiceOp) GetCategory(sid uint64, lang, tok string) (*GetCategoryResponse, error) {
+	return s.GetCategoryWithContext(context.Background(), sid, lang, tok)
+}
+
+func (s *ProductServiceOp) GetCategoryWithContext(ctx context.Context, sid uint64, lang, tok string) (*GetCategoryResponse, error) {
 	path := "/product/get_category"
 
 	opt := GetCategoryRequest{
@@ -58,7 +101,7 @@ func (s *ProductServiceOp) GetCategory(sid uint64, lang, tok string) (*GetCatego
 	}
 
 	resp := new(GetCategoryResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -90,6 +133,10 @@ type Brand struct {
 }
 
 func (s *ProductServiceOp) GetBrandList(sid, cid uint64, status, offset, pageSize int, tok string) (*GetBrandListResponse, error) {
+	return s.GetBrandListWithContext(context.Background(), sid, cid, status, offset, pageSize, tok)
+}
+
+func (s *ProductServiceOp) GetBrandListWithContext(ctx context.Context, sid, cid uint64, status, offset, pageSize int, tok string) (*GetBrandListResponse, error) {
 	path := "/product/get_brand_list"
 
 	opt := GetBrandListRequest{
@@ -100,7 +147,7 @@ func (s *ProductServiceOp) GetBrandList(sid, cid uint64, status, offset, pageSiz
 	}
 
 	resp := new(GetBrandListResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -125,6 +172,10 @@ type DaysToShipLimit struct {
 }
 
 func (s *ProductServiceOp) GetDTSLimit(sid, cid uint64, tok string) (*GetDTSLimitResponse, error) {
+	return s.GetDTSLimitWithContext(context.Background(), sid, cid, tok)
+}
+
+func (s *ProductServiceOp) GetDTSLimitWithContext(ctx context.Context, sid, cid uint64, tok string) (*GetDTSLimitResponse, error) {
 	path := "/product/get_dts_limit"
 
 	opt := GetDTSLimitRequest{
@@ -132,7 +183,7 @@ func (s *ProductServiceOp) GetDTSLimit(sid, cid uint64, tok string) (*GetDTSLimi
 	}
 
 	resp := new(GetDTSLimitResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -183,6 +234,10 @@ type ParentBrand struct {
 }
 
 func (s *ProductServiceOp) GetAttributes(sid, cid uint64, lang, tok string) (*GetAttributesResponse, error) {
+	return s.GetAttributesWithContext(context.Background(), sid, cid, lang, tok)
+}
+
+func (s *ProductServiceOp) GetAttributesWithContext(ctx context.Context, sid, cid uint64, lang, tok string) (*GetAttributesResponse, error) {
 	path := "/product/get_attributes"
 
 	opt := GetAttibutesRequest{
@@ -191,7 +246,7 @@ func (s *ProductServiceOp) GetAttributes(sid, cid uint64, lang, tok string) (*Ge
 	}
 
 	resp := new(GetAttributesResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -210,6 +265,10 @@ type SupportSizeChartResponseData struct {
 }
 
 func (s *ProductServiceOp) SupportSizeChart(sid, cid uint64, tok string) (*SupportSizeChartResponse, error) {
+	return s.SupportSizeChartWithContext(context.Background(), sid, cid, tok)
+}
+
+func (s *ProductServiceOp) SupportSizeChartWithContext(ctx context.Context, sid, cid uint64, tok string) (*SupportSizeChartResponse, error) {
 	path := "/product/support_size_chart"
 
 	opt := SupportSizeChartRequest{
@@ -217,7 +276,7 @@ func (s *ProductServiceOp) SupportSizeChart(sid, cid uint64, tok string) (*Suppo
 	}
 
 	resp := new(SupportSizeChartResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -226,13 +285,17 @@ type UpdateSizeChartResponse struct {
 }
 
 func (s *ProductServiceOp) UpdateSizeChart(sid, itemID uint64, sizeChart, tok string) (*UpdateSizeChartResponse, error) {
+	return s.UpdateSizeChartWithContext(context.Background(), sid, itemID, sizeChart, tok)
+}
+
+func (s *ProductServiceOp) UpdateSizeChartWithContext(ctx context.Context, sid, itemID uint64, sizeChart, tok string) (*UpdateSizeChartResponse, error) {
 	path := "/product/update_size_chart"
 	wrappedData := map[string]interface{}{
 		"item_id":    itemID,
 		"size_chart": sizeChart,
 	}
 	resp := new(UpdateSizeChartResponse)
-	err := s.client.WithShop(sid, tok).Post(path, wrappedData, resp)
+	err := s.client.WithShop(sid, tok).PostWithContext(ctx, path, wrappedData, resp)
 	return resp, err
 }
 
@@ -373,13 +436,17 @@ type AddItemResponseData struct {
 }
 
 func (s *ProductServiceOp) AddItem(sid uint64, item AddItemRequest, tok string) (*AddItemResponse, error) {
+	return s.AddItemWithContext(context.Background(), sid, item, tok)
+}
+
+func (s *ProductServiceOp) AddItemWithContext(ctx context.Context, sid uint64, item AddItemRequest, tok string) (*AddItemResponse, error) {
 	path := "/product/add_item"
 	resp := new(AddItemResponse)
 	req, err := StructToMap(item)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -465,13 +532,17 @@ type PriceInfo struct {
 }
 
 func (s *ProductServiceOp) InitTierVariation(sid uint64, vars InitTierVariationRequest, tok string) (*InitTierVariationResponse, error) {
+	return s.InitTierVariationWithContext(context.Background(), sid, vars, tok)
+}
+
+func (s *ProductServiceOp) InitTierVariationWithContext(ctx context.Context, sid uint64, vars InitTierVariationRequest, tok string) (*InitTierVariationResponse, error) {
 	path := "/product/init_tier_variation"
 	resp := new(InitTierVariationResponse)
 	req, err := StructToMap(vars)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -499,13 +570,17 @@ type AddModelResponseData struct {
 }
 
 func (s *ProductServiceOp) AddModel(sid uint64, vars AddModelRequest, tok string) (*AddModelResponse, error) {
+	return s.AddModelWithContext(context.Background(), sid, vars, tok)
+}
+
+func (s *ProductServiceOp) AddModelWithContext(ctx context.Context, sid uint64, vars AddModelRequest, tok string) (*AddModelResponse, error) {
 	path := "/product/add_model"
 	resp := new(AddModelResponse)
 	req, err := StructToMap(vars)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -525,6 +600,10 @@ type GetModelListResponseData struct {
 }
 
 func (s *ProductServiceOp) GetModelList(sid, itemID uint64, tok string) (*GetModelListResponse, error) {
+	return s.GetModelListWithContext(context.Background(), sid, itemID, tok)
+}
+
+func (s *ProductServiceOp) GetModelListWithContext(ctx context.Context, sid, itemID uint64, tok string) (*GetModelListResponse, error) {
 	path := "/product/get_model_list"
 
 	opt := GetModelListRequest{
@@ -532,7 +611,7 @@ func (s *ProductServiceOp) GetModelList(sid, itemID uint64, tok string) (*GetMod
 	}
 
 	resp := new(GetModelListResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -559,6 +638,10 @@ type ItemBaseInfoData struct {
 }
 
 func (s *ProductServiceOp) GetItemBaseInfo(sid uint64, itemIDs []uint64, tok string) (*GetItemBaseInfoResponse, error) {
+	return s.GetItemBaseInfoWithContext(context.Background(), sid, itemIDs, tok)
+}
+
+func (s *ProductServiceOp) GetItemBaseInfoWithContext(ctx context.Context, sid uint64, itemIDs []uint64, tok string) (*GetItemBaseInfoResponse, error) {
 	path := "/product/get_item_base_info"
 
 	opt := GetItemBaseInfoRequest{
@@ -566,17 +649,170 @@ func (s *ProductServiceOp) GetItemBaseInfo(sid uint64, itemIDs []uint64, tok str
 	}
 
 	resp := new(GetItemBaseInfoResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
+// https://open.shopee.com/documents/v2/v2.product.get_item_list?module=89&type=1
+const (
+	ItemStatusNormal       = "NORMAL"
+	ItemStatusBanned       = "BANNED"
+	ItemStatusUnlist       = "UNLIST"
+	ItemStatusReviewing    = "REVIEWING"
+	ItemStatusSellerDelete = "SELLER_DELETE"
+	ItemStatusShopeeDelete = "SHOPEE_DELETE"
+
+	ItemListMaxPageSize   = 100
+	ItemBaseInfoMaxIDList = 50
+)
+
+type GetItemListRequest struct {
+	Offset         int      `url:"offset"`
+	PageSize       int      `url:"page_size"`
+	ItemStatus     []string `url:"item_status"` // at least one of the ItemStatus constants
+	UpdateTimeFrom int64    `url:"update_time_from,omitempty"`
+	UpdateTimeTo   int64    `url:"update_time_to,omitempty"`
+}
+
+type GetItemListResponse struct {
+	BaseResponse
+
+	Response GetItemListResponseData `json:"response"`
+}
+
+type GetItemListResponseData struct {
+	Item        []GetItemListResponseDataItem `json:"item"`
+	TotalCount  int                           `json:"total_count"`
+	HasNextPage bool                          `json:"has_next_page"`
+	NextOffset  int                           `json:"next_offset"`
+}
+
+type GetItemListResponseDataItem struct {
+	ItemID     uint64 `json:"item_id"`
+	ItemStatus string `json:"item_status"`
+	UpdateTime int64  `json:"update_time"`
+}
+
+func (s *ProductServiceOp) GetItemList(sid uint64, opt GetItemListRequest, tok string) (*GetItemListResponse, error) {
+	return s.GetItemListWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *ProductServiceOp) GetItemListWithContext(ctx context.Context, sid uint64, opt GetItemListRequest, tok string) (*GetItemListResponse, error) {
+	path := "/product/get_item_list"
+
+	if len(opt.ItemStatus) == 0 {
+		return nil, errors.New("get_item_list needs at least one item_status")
+	}
+
+	resp := new(GetItemListResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// ExportCatalogRequest selects the items exported by ExportCatalog
+type ExportCatalogRequest struct {
+	ItemStatus     []string // defaults to NORMAL, BANNED, UNLIST and REVIEWING
+	UpdateTimeFrom int64    // optional
+	UpdateTimeTo   int64    // optional
+	PageSize       int      // defaults to ItemListMaxPageSize
+
+	// OnMissing is called for the items listed by get_item_list that
+	// get_item_base_info does not return, e.g. deleted meanwhile. Optional.
+	OnMissing func(itemID uint64)
+}
+
+// CatalogItem is an item with its models. Models is empty for items without
+// variations, their price and stock are in PriceInfo and StockInfo.
+type CatalogItem struct {
+	ItemBaseInfoData
+
+	TierVariation []TierVariation `json:"tier_variation,omitempty"`
+	Models        []Model         `json:"models,omitempty"`
+}
+
+// ExportCatalog walks the items of the shop sid with get_item_list, fetches
+// their base info in batches of ItemBaseInfoMaxIDList and the models of those
+// having some, and calls fn for every item in get_item_list order. It stops
+// with ErrPageNotAdvancing when get_item_list does not move to the next page.
+func (s *ProductServiceOp) ExportCatalog(ctx context.Context, sid uint64, opt ExportCatalogRequest, tok string, fn func(CatalogItem) error) error {
+	req := GetItemListRequest{
+		PageSize:       opt.PageSize,
+		ItemStatus:     opt.ItemStatus,
+		UpdateTimeFrom: opt.UpdateTimeFrom,
+		UpdateTimeTo:   opt.UpdateTimeTo,
+	}
+	if req.PageSize <= 0 || req.PageSize > ItemListMaxPageSize {
+		req.PageSize = ItemListMaxPageSize
+	}
+	if len(req.ItemStatus) == 0 {
+		req.ItemStatus = []string{ItemStatusNormal, ItemStatusBanned, ItemStatusUnlist, ItemStatusReviewing}
+	}
+
+	// flush fetches the buffered items in full batches, and also the last
+	// partial batch when all is set
+	var ids []uint64
+	flush := func(all bool) error {
+		for len(ids) >= ItemBaseInfoMaxIDList || (all && len(ids) > 0) {
+			n := len(ids)
+			if n > ItemBaseInfoMaxIDList {
+				n = ItemBaseInfoMaxIDList
+			}
+			res, err := s.GetItemBaseInfoWithContext(ctx, sid, ids[:n], tok)
+			if err != nil {
+				return err
+			}
+			infos := make(map[uint64]ItemBaseInfoData, len(res.Response.ItemList))
+			for _, info := range res.Response.ItemList {
+				infos[info.ItemID] = info
+			}
+			for _, id := range ids[:n] {
+				info, ok := infos[id]
+				if !ok {
+					if opt.OnMissing != nil {
+						opt.OnMissing(id)
+					}
+					continue
+				}
+				item := CatalogItem{ItemBaseInfoData: info}
+				if info.HasModel {
+					models, err := s.GetModelListWithContext(ctx, sid, info.ItemID, tok)
+					if err != nil {
+						return err
+					}
+					item.TierVariation = models.Response.TierVariation
+					item.Models = models.Response.Model
+				}
+				if err := fn(item); err != nil {
+					return err
+				}
+			}
+			ids = ids[n:]
+		}
+		return nil
+	}
+
+	p := NewItemListPager(s, sid, req, tok)
+	err := p.Each(ctx, func(it GetItemListResponseDataItem) error {
+		ids = append(ids, it.ItemID)
+		return flush(false)
+	})
+	if err != nil {
+		return err
+	}
+	return flush(true)
+}
+
 func (s *ProductServiceOp) DeleteItem(sid, itemID uint64, tok string) (*BaseResponse, error) {
+	return s.DeleteItemWithContext(context.Background(), sid, itemID, tok)
+}
+
+func (s *ProductServiceOp) DeleteItemWithContext(ctx context.Context, sid, itemID uint64, tok string) (*BaseResponse, error) {
 	path := "/product/delete_item"
 	resp := new(BaseResponse)
 	req := map[string]interface{}{
 		"item_id": itemID,
 	}
-	err := s.client.WithShop(sid, tok).Post(path, req, resp)
+	err := s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -604,13 +840,17 @@ type UpdateItemResponseData struct {
 }
 
 func (s *ProductServiceOp) UpdateItem(sid uint64, item UpdateItemRequest, tok string) (*UpdateItemResponse, error) {
+	return s.UpdateItemWithContext(context.Background(), sid, item, tok)
+}
+
+func (s *ProductServiceOp) UpdateItemWithContext(ctx context.Context, sid uint64, item UpdateItemRequest, tok string) (*UpdateItemResponse, error) {
 	path := "/product/update_item"
 	resp := new(UpdateItemResponse)
 	req, err := StructToMap(item)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -645,17 +885,25 @@ type UnlistItemResponseDataSuccess struct {
 }
 
 func (s *ProductServiceOp) UnlistItem(sid uint64, data UnlistItemRequest, tok string) (*UnlistItemResponse, error) {
+	return s.UnlistItemWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *ProductServiceOp) UnlistItemWithContext(ctx context.Context, sid uint64, data UnlistItemRequest, tok string) (*UnlistItemResponse, error) {
 	path := "/product/unlist_item"
 	resp := new(UnlistItemResponse)
 	req, err := StructToMap(data)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
 func (s *ProductServiceOp) DeleteModel(sid, itemID, modelID uint64, tok string) (*BaseResponse, error) {
+	return s.DeleteModelWithContext(context.Background(), sid, itemID, modelID, tok)
+}
+
+func (s *ProductServiceOp) DeleteModelWithContext(ctx context.Context, sid, itemID, modelID uint64, tok string) (*BaseResponse, error) {
 	path := "/product/delete_model"
 	resp := new(BaseResponse)
 	req := map[string]interface{}{
@@ -663,7 +911,7 @@ func (s *ProductServiceOp) DeleteModel(sid, itemID, modelID uint64, tok string)
 		"model_id": modelID,
 	}
 
-	err := s.client.WithShop(sid, tok).Post(path, req, resp)
+	err := s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -683,13 +931,17 @@ type UpdateModelResponse struct {
 }
 
 func (s *ProductServiceOp) UpdateModel(sid uint64, data UpdateModelRequest, tok string) (*UpdateModelResponse, error) {
+	return s.UpdateModelWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *ProductServiceOp) UpdateModelWithContext(ctx context.Context, sid uint64, data UpdateModelRequest, tok string) (*UpdateModelResponse, error) {
 	path := "/product/update_model"
 	resp := new(UpdateModelResponse)
 	req, err := StructToMap(data)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -725,13 +977,17 @@ type UpdatePriceResponseDataSuccess struct {
 }
 
 func (s *ProductServiceOp) UpdatePrice(sid uint64, data UpdatePriceRequest, tok string) (*UpdatePriceResponse, error) {
+	return s.UpdatePriceWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *ProductServiceOp) UpdatePriceWithContext(ctx context.Context, sid uint64, data UpdatePriceRequest, tok string) (*UpdatePriceResponse, error) {
 	path := "/product/update_price"
 	resp := new(UpdatePriceResponse)
 	req, err := StructToMap(data)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -767,13 +1023,17 @@ type UpdateStockResponseDataSuccess struct {
 }
 
 func (s *ProductServiceOp) UpdateStock(sid uint64, data UpdateStockRequest, tok string) (*UpdateStockResponse, error) {
+	return s.UpdateStockWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *ProductServiceOp) UpdateStockWithContext(ctx context.Context, sid uint64, data UpdateStockRequest, tok string) (*UpdateStockResponse, error) {
 	path := "/product/update_stock"
 	resp := new(UpdateStockResponse)
 	req, err := StructToMap(data)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
 
@@ -793,6 +1053,10 @@ type CategoryRecommendResponseData struct {
 
 // https://open.shopee.com/documents?module=89&type=1&id=702&version=2
 func (s *ProductServiceOp) CategoryRecommend(sid uint64, itemName, tok string) (*CategoryRecommendResponse, error) {
+	return s.CategoryRecommendWithContext(context.Background(), sid, itemName, tok)
+}
+
+func (s *ProductServiceOp) CategoryRecommendWithContext(ctx context.Context, sid uint64, itemName, tok string) (*CategoryRecommendResponse, error) {
 	path := "/product/category_recommend" // Infact is recommend
 
 	opt := CategoryRecommendRequest{
@@ -800,7 +1064,7 @@ func (s *ProductServiceOp) CategoryRecommend(sid uint64, itemName, tok string) (
 	}
 
 	resp := new(CategoryRecommendResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -844,6 +1108,10 @@ type ReservedStockInfo struct {
 }
 
 func (s *ProductServiceOp) GetItemPromotion(sid uint64, itemIDs []uint64, tok string) (*GetItemPromotionResponse, error) {
+	return s.GetItemPromotionWithContext(context.Background(), sid, itemIDs, tok)
+}
+
+func (s *ProductServiceOp) GetItemPromotionWithContext(ctx context.Context, sid uint64, itemIDs []uint64, tok string) (*GetItemPromotionResponse, error) {
 	path := "/product/get_item_promotion"
 
 	opt := GetItemPromotionRequest{
@@ -851,7 +1119,222 @@ func (s *ProductServiceOp) GetItemPromotion(sid uint64, itemIDs []uint64, tok st
 	}
 
 	resp := new(GetItemPromotionResponse)
-	err := s.client.WithShop(sid, tok).Get(path, resp, opt)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.product.get_item_extra_info?module=89&type=1
+type GetItemExtraInfoRequest struct {
+	ItemIDList []uint64 `url:"item_id_list"`
+}
+
+type GetItemExtraInfoResponse struct {
+	BaseResponse
+
+	Response GetItemExtraInfoResponseData `json:"response"`
+}
+
+type GetItemExtraInfoResponseData struct {
+	ItemList []ItemExtraInfo `json:"item_list"`
+}
+
+type ItemExtraInfo struct {
+	ItemID       uint64  `json:"item_id"`
+	Sale         int     `json:"sale"`
+	Views        int     `json:"views"`
+	Likes        int     `json:"likes"`
+	RatingStar   float64 `json:"rating_star"`
+	CommentCount int     `json:"comment_count"`
+}
+
+// ByItemID indexes the extra info by item id, to join it with the
+// ItemBaseInfoData of GetItemBaseInfo
+func (r *GetItemExtraInfoResponseData) ByItemID() map[uint64]ItemExtraInfo {
+	m := make(map[uint64]ItemExtraInfo, len(r.ItemList))
+	for _, it := range r.ItemList {
+		m[it.ItemID] = it
+	}
+	return m
+}
+
+func (s *ProductServiceOp) GetItemExtraInfo(sid uint64, itemIDs []uint64, tok string) (*GetItemExtraInfoResponse, error) {
+	return s.GetItemExtraInfoWithContext(context.Background(), sid, itemIDs, tok)
+}
+
+func (s *ProductServiceOp) GetItemExtraInfoWithContext(ctx context.Context, sid uint64, itemIDs []uint64, tok string) (*GetItemExtraInfoResponse, error) {
+	path := "/product/get_item_extra_info"
+
+	opt := GetItemExtraInfoRequest{
+		ItemIDList: itemIDs,
+	}
+
+	resp := new(GetItemExtraInfoResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.product.search_item?module=89&type=1
+const (
+	AttributeStatusLackRequired = 1
+	AttributeStatusLackOptional = 2
+)
+
+// SearchItemRequest searches by one of ItemName, ItemSKU or AttributeStatus
+type SearchItemRequest struct {
+	Offset          string   `url:"offset,omitempty"`
+	PageSize        int      `url:"page_size"`
+	ItemName        string   `url:"item_name,omitempty"`
+	AttributeStatus int      `url:"attribute_status,omitempty"` // AttributeStatusLackRequired or AttributeStatusLackOptional
+	ItemSKU         string   `url:"item_sku,omitempty"`
+	ItemStatus      []string `url:"item_status,omitempty"`
+}
+
+type SearchItemResponse struct {
+	BaseResponse
+
+	Response SearchItemResponseData `json:"response"`
+}
+
+type SearchItemResponseData struct {
+	ItemIDList []uint64 `json:"item_id_list"`
+	TotalCount int      `json:"total_count"`
+	NextOffset string   `json:"next_offset"`
+}
+
+func (s *ProductServiceOp) SearchItem(sid uint64, opt SearchItemRequest, tok string) (*SearchItemResponse, error) {
+	return s.SearchItemWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *ProductServiceOp) SearchItemWithContext(ctx context.Context, sid uint64, opt SearchItemRequest, tok string) (*SearchItemResponse, error) {
+	path := "/product/search_item"
+
+	resp := new(SearchItemResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.product.boost_item?module=89&type=1
+const BoostItemMaxIDList = 5
+
+type BoostItemRequest struct {
+	ItemIDList []uint64 `json:"item_id_list"`
+}
+
+type BoostItemResponse struct {
+	BaseResponse
+
+	Response BoostItemResponseData `json:"response"`
+}
+
+type BoostItemResponseData struct {
+	FailureList []BoostItemResponseDataFail  `json:"failure_list"`
+	SuccessList BoostItemResponseDataSuccess `json:"success_list"`
+}
+
+type BoostItemResponseDataFail struct {
+	ItemID       uint64 `json:"item_id"`
+	FailedReason string `json:"failed_reason"`
+}
+
+type BoostItemResponseDataSuccess struct {
+	ItemIDList []uint64 `json:"item_id_list"`
+}
+
+func (s *ProductServiceOp) BoostItem(sid uint64, itemIDs []uint64, tok string) (*BoostItemResponse, error) {
+	return s.BoostItemWithContext(context.Background(), sid, itemIDs, tok)
+}
+
+func (s *ProductServiceOp) BoostItemWithContext(ctx context.Context, sid uint64, itemIDs []uint64, tok string) (*BoostItemResponse, error) {
+	path := "/product/boost_item"
+	resp := new(BoostItemResponse)
+	req, err := StructToMap(BoostItemRequest{ItemIDList: itemIDs})
+	if err != nil {
+		return nil, err
+	}
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.product.get_boosted_list?module=89&type=1
+type GetBoostedListResponse struct {
+	BaseResponse
+
+	Response GetBoostedListResponseData `json:"response"`
+}
+
+type GetBoostedListResponseData struct {
+	ItemList []BoostedItem `json:"item_list"`
+}
+
+type BoostedItem struct {
+	ItemID         uint64 `json:"item_id"`
+	CoolDownSecond int    `json:"cool_down_second"`
+}
+
+func (s *ProductServiceOp) GetBoostedList(sid uint64, tok string) (*GetBoostedListResponse, error) {
+	return s.GetBoostedListWithContext(context.Background(), sid, tok)
+}
+
+func (s *ProductServiceOp) GetBoostedListWithContext(ctx context.Context, sid uint64, tok string) (*GetBoostedListResponse, error) {
+	path := "/product/get_boosted_list"
+
+	resp := new(GetBoostedListResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, nil)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.product.get_item_limit?module=89&type=1
+type GetItemLimitRequest struct {
+	CategoryID uint64 `url:"category_id,omitempty"`
+}
+
+type GetItemLimitResponse struct {
+	BaseResponse
+
+	Response ItemLimit `json:"response"`
+}
+
+type ItemLimit struct {
+	PriceLimit                     PriceLimit  `json:"price_limit"`
+	WholesalePriceThreshold        PriceLimit  `json:"wholesale_price_threshold"`
+	StockLimit                     RangeLimit  `json:"stock_limit"`
+	ItemCountLimit                 MaxLimit    `json:"item_count_limit"`
+	ItemNameLengthLimit            RangeLimit  `json:"item_name_length_limit"`
+	ItemImageCountLimit            RangeLimit  `json:"item_image_count_limit"`
+	ItemDescriptionLengthLimit     RangeLimit  `json:"item_description_length_limit"`
+	TierVariationNameLengthLimit   RangeLimit  `json:"tier_variation_name_length_limit"`
+	TierVariationOptionLengthLimit RangeLimit  `json:"tier_variation_option_length_limit"`
+	ItemDescriptionImageCountLimit *RangeLimit `json:"item_description_image_count_limit,omitempty"`
+	ExtendedDescriptionLengthLimit *RangeLimit `json:"extended_description_length_limit,omitempty"`
+}
+
+type PriceLimit struct {
+	MinLimit float64 `json:"min_limit"`
+	MaxLimit float64 `json:"max_limit"`
+}
+
+type RangeLimit struct {
+	MinLimit int `json:"min_limit"`
+	MaxLimit int `json:"max_limit"`
+}
+
+type MaxLimit struct {
+	MaxLimit int `json:"max_limit"`
+}
+
+func (s *ProductServiceOp) GetItemLimit(sid, cid uint64, tok string) (*GetItemLimitResponse, error) {
+	return s.GetItemLimitWithContext(context.Background(), sid, cid, tok)
+}
+
+func (s *ProductServiceOp) GetItemLimitWithContext(ctx context.Context, sid, cid uint64, tok string) (*GetItemLimitResponse, error) {
+	path := "/product/get_item_limit"
+
+	opt := GetItemLimitRequest{
+		CategoryID: cid,
+	}
+
+	resp := new(GetItemLimitResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
 	return resp, err
 }
 
@@ -865,12 +1348,16 @@ type UpdateTierVariationResponse struct {
 }
 
 func (s *ProductServiceOp) UpdateTierVariation(sid uint64, data UpdateTierVariationRequest, tok string) (*UpdateTierVariationResponse, error) {
+	return s.UpdateTierVariationWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *ProductServiceOp) UpdateTierVariationWithContext(ctx context.Context, sid uint64, data UpdateTierVariationRequest, tok string) (*UpdateTierVariationResponse, error) {
 	path := "/product/update_tier_variation"
 	resp := new(UpdateTierVariationResponse)
 	req, err := StructToMap(data)
 	if err != nil {
 		return nil, err
 	}
-	err = s.client.WithShop(sid, tok).Post(path, req, resp)
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
 	return resp, err
 }
@@ -1,10 +1,14 @@
 package goshopee
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/passwind/go-shopee-v2/shopeetest"
 )
 
 func Test_GetCategory(t *testing.T) {
@@ -242,6 +246,122 @@ func Test_GetItemBaseInfo(t *testing.T) {
 	}
 }
 
+func Test_GetItemList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_item_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if fmt.Sprint(q["item_status"]) != "[NORMAL UNLIST]" || q.Get("offset") != "0" || q.Get("update_time_from") != "1615791500" {
+				t.Errorf("get_item_list query %s", req.URL.RawQuery)
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_item_list_resp.json")), nil
+		})
+
+	res, err := client.Product.GetItemList(shopID, GetItemListRequest{
+		PageSize:       2,
+		ItemStatus:     []string{ItemStatusNormal, ItemStatusUnlist},
+		UpdateTimeFrom: 1615791500,
+	}, accessToken)
+	if err != nil {
+		t.Fatalf("Product.GetItemList error: %s", err)
+	}
+	if len(res.Response.Item) != 2 || res.Response.Item[1].ItemStatus != ItemStatusUnlist || res.Response.NextOffset != 2 || !res.Response.HasNextPage {
+		t.Errorf("Product.GetItemList returned %+v", res.Response)
+	}
+
+	if _, err := client.Product.GetItemList(shopID, GetItemListRequest{PageSize: 2}, accessToken); err == nil {
+		t.Errorf("Product.GetItemList without item_status returned no error")
+	}
+}
+
+func Test_ExportCatalog(t *testing.T) {
+	srv := shopeetest.NewServer(12345678, "hush")
+	defer srv.Close()
+	srv.AddShop(&shopeetest.Shop{ShopID: shopID})
+	for i := 0; i < 120; i++ {
+		item := &shopeetest.Item{Name: fmt.Sprintf("item %d", i), Price: 10, Stock: i}
+		if i%40 == 0 {
+			item.Models = []*shopeetest.Model{{SKU: "S", Stock: 1}, {SKU: "M", Stock: 2}}
+		}
+		if i == 7 {
+			item.Status = ItemStatusSellerDelete
+		}
+		srv.AddItem(shopID, item)
+	}
+	tok := srv.IssueToken(shopID)
+	c := NewClient(App{PartnerID: srv.PartnerID, PartnerKey: srv.PartnerKey, APIURL: srv.URL})
+
+	var items []CatalogItem
+	err := c.Product.ExportCatalog(context.Background(), shopID, ExportCatalogRequest{PageSize: 30}, tok, func(it CatalogItem) error {
+		items = append(items, it)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Product.ExportCatalog error: %s", err)
+	}
+
+	if len(items) != 119 {
+		t.Fatalf("Product.ExportCatalog returned %d items, expected 119", len(items))
+	}
+	var withModels int
+	for _, it := range items {
+		if it.HasModel {
+			withModels++
+			if len(it.Models) != 2 || it.Models[1].StockInfo[0].NormalStock != 2 {
+				t.Errorf("item %d has models %+v", it.ItemID, it.Models)
+			}
+		} else if len(it.StockInfo) != 1 {
+			t.Errorf("item %d has stock %+v", it.ItemID, it.StockInfo)
+		}
+	}
+	if withModels != 3 {
+		t.Errorf("%d items with models, expected 3", withModels)
+	}
+	if n := srv.Calls("/product/get_item_base_info"); n != 3 {
+		t.Errorf("%d get_item_base_info calls, expected 3", n)
+	}
+	if n := srv.Calls("/product/get_item_list"); n != 4 {
+		t.Errorf("%d get_item_list calls, expected 4", n)
+	}
+}
+
+func Test_ExportCatalogOrderAndStuckOffset(t *testing.T) {
+	setup()
+	defer teardown()
+
+	stuck := false
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_item_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			if stuck {
+				return httpmock.NewStringResponse(200, `{"response":{"item":[{"item_id":1}],"has_next_page":true,"next_offset":0}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"response":{"item":[{"item_id":3},{"item_id":1},{"item_id":2}],"has_next_page":false}}`), nil
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_item_base_info", app.APIURL),
+		httpmock.NewStringResponder(200, `{"response":{"item_list":[{"item_id":2},{"item_id":1}]}}`))
+
+	var ids, missing []uint64
+	opt := ExportCatalogRequest{OnMissing: func(id uint64) { missing = append(missing, id) }}
+	err := client.Product.ExportCatalog(context.Background(), shopID, opt, accessToken, func(it CatalogItem) error {
+		ids = append(ids, it.ItemID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Product.ExportCatalog error: %s", err)
+	}
+	if fmt.Sprint(ids) != "[1 2]" || fmt.Sprint(missing) != "[3]" {
+		t.Errorf("Product.ExportCatalog returned items %v and missing %v, expected [1 2] and [3]", ids, missing)
+	}
+
+	stuck = true
+	err = client.Product.ExportCatalog(context.Background(), shopID, ExportCatalogRequest{}, accessToken, func(CatalogItem) error { return nil })
+	if !errors.Is(err, ErrPageNotAdvancing) {
+		t.Errorf("Product.ExportCatalog returned %v, expected ErrPageNotAdvancing", err)
+	}
+}
+
 func Test_DeleteItem(t *testing.T) {
 	setup()
 	defer teardown()
@@ -437,6 +557,127 @@ func Test_GetItemPromotion(t *testing.T) {
 	}
 }
 
+func Test_GetItemExtraInfo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_item_extra_info", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_item_extra_info_resp.json")))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_item_base_info", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_item_base_info_resp.json")))
+
+	res, err := client.Product.GetItemExtraInfo(shopID, []uint64{10311771375, 10311771376}, accessToken)
+	if err != nil {
+		t.Fatalf("Product.GetItemExtraInfo error: %s", err)
+	}
+
+	t.Logf("Product.GetItemExtraInfo: %#v", res)
+
+	var expected float64 = 4.7
+	if res.Response.ItemList[0].RatingStar != expected {
+		t.Errorf("RatingStar returned %+v, expected %+v", res.Response.ItemList[0].RatingStar, expected)
+	}
+
+	base, err := client.Product.GetItemBaseInfo(shopID, []uint64{10311771375}, accessToken)
+	if err != nil {
+		t.Fatalf("Product.GetItemBaseInfo error: %s", err)
+	}
+	extra := res.Response.ByItemID()
+	for _, it := range base.Response.ItemList {
+		if _, ok := extra[it.ItemID]; !ok {
+			t.Errorf("no extra info for item %d", it.ItemID)
+		}
+	}
+}
+
+func Test_SearchItem(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/search_item", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if q.Get("item_sku") != "SKU-1" || q.Get("item_name") != "" || q.Get("page_size") != "2" {
+				t.Errorf("search_item query %s", req.URL.RawQuery)
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("search_item_resp.json")), nil
+		})
+
+	res, err := client.Product.SearchItem(shopID, SearchItemRequest{PageSize: 2, ItemSKU: "SKU-1"}, accessToken)
+	if err != nil {
+		t.Fatalf("Product.SearchItem error: %s", err)
+	}
+
+	var expected string = "2"
+	if res.Response.NextOffset != expected || len(res.Response.ItemIDList) != 2 {
+		t.Errorf("NextOffset returned %+v, expected %+v", res.Response.NextOffset, expected)
+	}
+}
+
+func Test_BoostItem(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/product/boost_item", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("boost_item_resp.json")))
+
+	res, err := client.Product.BoostItem(shopID, []uint64{3000142341, 3000142342}, accessToken)
+	if err != nil {
+		t.Fatalf("Product.BoostItem error: %s", err)
+	}
+
+	t.Logf("Product.BoostItem: %#v", res)
+
+	var expected uint64 = 3000142341
+	if res.Response.SuccessList.ItemIDList[0] != expected {
+		t.Errorf("ItemID returned %+v, expected %+v", res.Response.SuccessList.ItemIDList[0], expected)
+	}
+	if res.Response.FailureList[0].FailedReason == "" {
+		t.Errorf("FailureList returned %+v", res.Response.FailureList)
+	}
+}
+
+func Test_GetBoostedList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_boosted_list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_boosted_list_resp.json")))
+
+	res, err := client.Product.GetBoostedList(shopID, accessToken)
+	if err != nil {
+		t.Fatalf("Product.GetBoostedList error: %s", err)
+	}
+
+	var expected int = 14326
+	if res.Response.ItemList[0].CoolDownSecond != expected {
+		t.Errorf("CoolDownSecond returned %+v, expected %+v", res.Response.ItemList[0].CoolDownSecond, expected)
+	}
+}
+
+func Test_GetItemLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_item_limit", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_item_limit_resp.json")))
+
+	res, err := client.Product.GetItemLimit(shopID, 100182, accessToken)
+	if err != nil {
+		t.Fatalf("Product.GetItemLimit error: %s", err)
+	}
+
+	t.Logf("Product.GetItemLimit: %#v", res)
+
+	var expected int = 120
+	if res.Response.ItemNameLengthLimit.MaxLimit != expected {
+		t.Errorf("ItemNameLengthLimit.MaxLimit returned %+v, expected %+v", res.Response.ItemNameLengthLimit.MaxLimit, expected)
+	}
+	if res.Response.PriceLimit.MinLimit != 0.1 {
+		t.Errorf("PriceLimit.MinLimit returned %+v", res.Response.PriceLimit.MinLimit)
+	}
+}
+
 func Test_UpdateTierVariation(t *testing.T) {
 	setup()
 	defer teardown()
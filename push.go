@@ -0,0 +1,77 @@
+package goshopee
+
+import "context"
+
+// https://open.shopee.com/documents/v2/v2.push.set_app_push_config?module=105&type=1
+type PushService interface {
+	SetAppPushConfig(SetAppPushConfigRequest) (*SetAppPushConfigResponse, error)
+	SetAppPushConfigWithContext(context.Context, SetAppPushConfigRequest) (*SetAppPushConfigResponse, error)
+	GetAppPushConfig() (*GetAppPushConfigResponse, error)
+	GetAppPushConfigWithContext(context.Context) (*GetAppPushConfigResponse, error)
+}
+
+type PushServiceOp struct {
+	client *Client
+}
+
+// SetAppPushConfigRequest turns pushes on and off. SetPushConfigOn and
+// SetPushConfigOff take push codes, see PushCodeOrderStatus etc.
+type SetAppPushConfigRequest struct {
+	CallbackURL      string   `json:"callback_url,omitempty"`
+	SetPushConfigOn  []int    `json:"set_push_config_on,omitempty"`
+	SetPushConfigOff []int    `json:"set_push_config_off,omitempty"`
+	BlockedShopID    []uint64 `json:"blocked_shop_id,omitempty"`
+}
+
+type SetAppPushConfigResponse struct {
+	BaseResponse
+
+	Response SetAppPushConfigResponseData `json:"response"`
+}
+
+type SetAppPushConfigResponseData struct {
+	Result string `json:"result"`
+}
+
+func (s *PushServiceOp) SetAppPushConfig(data SetAppPushConfigRequest) (*SetAppPushConfigResponse, error) {
+	return s.SetAppPushConfigWithContext(context.Background(), data)
+}
+
+func (s *PushServiceOp) SetAppPushConfigWithContext(ctx context.Context, data SetAppPushConfigRequest) (*SetAppPushConfigResponse, error) {
+	path := "/push/set_app_push_config"
+	resp := new(SetAppPushConfigResponse)
+	req, err := StructToMap(data)
+	if err != nil {
+		return nil, err
+	}
+	err = s.client.PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+// https://open.shopee.com/documents/v2/v2.push.get_app_push_config?module=105&type=1
+type GetAppPushConfigResponse struct {
+	BaseResponse
+
+	Response GetAppPushConfigResponseData `json:"response"`
+}
+
+type GetAppPushConfigResponseData struct {
+	CallbackURL       string   `json:"callback_url"`
+	LivePushStatus    string   `json:"live_push_status"`
+	SuspendedTime     int64    `json:"suspended_time"`
+	PushConfigOnList  []int    `json:"push_config_on_list"`
+	PushConfigOffList []int    `json:"push_config_off_list"`
+	BlockedShopIDList []uint64 `json:"blocked_shop_id_list"`
+}
+
+func (s *PushServiceOp) GetAppPushConfig() (*GetAppPushConfigResponse, error) {
+	return s.GetAppPushConfigWithContext(context.Background())
+}
+
+func (s *PushServiceOp) GetAppPushConfigWithContext(ctx context.Context) (*GetAppPushConfigResponse, error) {
+	path := "/push/get_app_push_config"
+
+	resp := new(GetAppPushConfigResponse)
+	err := s.client.GetWithContext(ctx, path, resp, nil)
+	return resp, err
+}
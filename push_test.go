@@ -0,0 +1,63 @@
+package goshopee
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_SetAppPushConfig(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/push/set_app_push_config", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if q.Get("shop_id") != "" || q.Get("access_token") != "" {
+				return httpmock.NewStringResponse(400, `{"error":"error_param","message":"public api signed as shop api"}`), nil
+			}
+			var body SetAppPushConfigRequest
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil || len(body.SetPushConfigOn) != 2 {
+				return httpmock.NewStringResponse(400, `{"error":"error_param","message":"bad body"}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("set_app_push_config_resp.json")), nil
+		})
+
+	req := SetAppPushConfigRequest{
+		CallbackURL:     "https://example.com/shopee/push",
+		SetPushConfigOn: []int{PushCodeOrderStatus, PushCodeOrderTrackingNo},
+	}
+	res, err := client.Push.SetAppPushConfig(req)
+	if err != nil {
+		t.Errorf("Push.SetAppPushConfig error: %s", err)
+	}
+
+	t.Logf("Push.SetAppPushConfig: %#v", res)
+
+	var expected string = "success"
+	if res.Response.Result != expected {
+		t.Errorf("Result returned %+v, expected %+v", res.Response.Result, expected)
+	}
+}
+
+func Test_GetAppPushConfig(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/push/get_app_push_config", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_app_push_config_resp.json")))
+
+	res, err := client.Push.GetAppPushConfig()
+	if err != nil {
+		t.Errorf("Push.GetAppPushConfig error: %s", err)
+	}
+
+	t.Logf("Push.GetAppPushConfig: %#v", res)
+
+	if len(res.Response.PushConfigOnList) != 2 || res.Response.PushConfigOnList[0] != PushCodeOrderStatus {
+		t.Errorf("PushConfigOnList returned %+v, expected [%d %d]", res.Response.PushConfigOnList, PushCodeOrderStatus, PushCodeOrderTrackingNo)
+	}
+}
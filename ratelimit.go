@@ -0,0 +1,259 @@
+package goshopee
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token bucket allowing Rate requests per second on average
+// and bursts of up to Burst requests. A Rate of 0 means no limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitKey identifies one bucket of a RateLimiter. Path is the api path
+// without the /api/v2 prefix, e.g. /product/update_stock.
+type RateLimitKey struct {
+	PartnerID  int
+	ShopID     uint64
+	MerchantID uint64
+	Path       string
+}
+
+func (k RateLimitKey) String() string {
+	switch {
+	case k.ShopID != 0:
+		return fmt.Sprintf("%d/shop:%d%s", k.PartnerID, k.ShopID, k.Path)
+	case k.MerchantID != 0:
+		return fmt.Sprintf("%d/merchant:%d%s", k.PartnerID, k.MerchantID, k.Path)
+	}
+	return fmt.Sprintf("%d%s", k.PartnerID, k.Path)
+}
+
+// RateLimitStats are the counters of one bucket
+type RateLimitStats struct {
+	Key RateLimitKey
+
+	// Requests sent through the bucket
+	Requests int64
+	// Requests that had to wait for a token, and the total time they waited
+	Throttled int64
+	Waited    time.Duration
+	// Responses where Shopee still reported the rate limit as exceeded
+	RateLimited int64
+}
+
+type rateLimitRule struct {
+	path  string
+	shop  uint64
+	limit RateLimit
+}
+
+type tokenBucket struct {
+	limit        RateLimit
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	stats        RateLimitStats
+}
+
+// RateLimiter throttles requests client side with one token bucket for each
+// partner, shop or merchant and api path, so bulk jobs stay under Shopee's
+// per-shop, per-api quotas. It is safe for concurrent use and may be shared
+// by several clients, see WithRateLimiter.
+type RateLimiter struct {
+	mu      sync.Mutex
+	def     RateLimit
+	rules   []rateLimitRule
+	buckets map[RateLimitKey]*tokenBucket
+
+	now func() time.Time
+}
+
+// NewRateLimiter returns a limiter applying def to every path and shop
+// without a more specific limit.
+func NewRateLimiter(def RateLimit) *RateLimiter {
+	return &RateLimiter{
+		def:     def,
+		buckets: make(map[RateLimitKey]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// SetLimit sets the limit of path for shop or merchant id. An empty path or a
+// zero id matches every path or every shop. When several limits match a
+// request, the one set for both path and id wins over the one for the path,
+// which wins over the one for the id. Buckets already in use keep their
+// limit.
+func (l *RateLimiter) SetLimit(path string, id uint64, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	path = normalizeRateLimitPath(path)
+	for i, r := range l.rules {
+		if r.path == path && r.shop == id {
+			l.rules[i].limit = limit
+			return
+		}
+	}
+	l.rules = append(l.rules, rateLimitRule{path: path, shop: id, limit: limit})
+}
+
+func (l *RateLimiter) limitFor(k RateLimitKey) RateLimit {
+	id := k.ShopID
+	if id == 0 {
+		id = k.MerchantID
+	}
+
+	best, score := l.def, 0
+	for _, r := range l.rules {
+		s := 0
+		if r.path != "" {
+			if r.path != k.Path {
+				continue
+			}
+			s += 2
+		}
+		if r.shop != 0 {
+			if r.shop != id {
+				continue
+			}
+			s++
+		}
+		if s >= score {
+			best, score = r.limit, s
+		}
+	}
+	return best
+}
+
+func (l *RateLimiter) bucket(k RateLimitKey) *tokenBucket {
+	b, ok := l.buckets[k]
+	if !ok {
+		limit := l.limitFor(k)
+		b = &tokenBucket{
+			limit:  limit,
+			tokens: float64(limit.Burst),
+			last:   l.now(),
+			stats:  RateLimitStats{Key: k},
+		}
+		l.buckets[k] = b
+	}
+	return b
+}
+
+// reserve takes a token from the bucket of k and returns how long the caller
+// has to wait before using it.
+func (l *RateLimiter) reserve(k RateLimitKey) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(k)
+	now := l.now()
+	b.stats.Requests++
+
+	var wait time.Duration
+	if b.limit.Rate > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate)
+		b.last = now
+		b.tokens--
+		if b.tokens < 0 {
+			wait = time.Duration(-b.tokens / b.limit.Rate * float64(time.Second))
+		}
+	}
+	if blocked := b.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+
+	if wait > 0 {
+		b.stats.Throttled++
+		b.stats.Waited += wait
+	}
+	return wait
+}
+
+// Wait blocks until a request for k may be sent, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, k RateLimitKey) error {
+	k.Path = normalizeRateLimitPath(k.Path)
+	wait := l.reserve(k)
+	if wait <= 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		l.mu.Lock()
+		b := l.buckets[k]
+		if b.limit.Rate > 0 {
+			b.tokens++
+		}
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// rateLimited records that Shopee rejected a request for k as over the
+// limit, holding back the requests for k for retryAfter.
+func (l *RateLimiter) rateLimited(k RateLimitKey, retryAfter time.Duration) {
+	k.Path = normalizeRateLimitPath(k.Path)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(k)
+	b.stats.RateLimited++
+	b.tokens = math.Min(b.tokens, 0)
+	if until := l.now().Add(retryAfter); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// info returns the state of the bucket of k as a RateLimitInfo
+func (l *RateLimiter) info(k RateLimitKey) RateLimitInfo {
+	k.Path = normalizeRateLimitPath(k.Path)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[k]
+	if !ok {
+		return RateLimitInfo{}
+	}
+	info := RateLimitInfo{
+		RequestCount: int(b.stats.Requests),
+		BucketSize:   b.limit.Burst,
+	}
+	if d := b.blockedUntil.Sub(l.now()); d > 0 {
+		info.RetryAfterSeconds = d.Seconds()
+	}
+	return info
+}
+
+// Stats returns the counters of every bucket used so far
+func (l *RateLimiter) Stats() []RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := make([]RateLimitStats, 0, len(l.buckets))
+	for _, b := range l.buckets {
+		stats = append(stats, b.stats)
+	}
+	return stats
+}
+
+func normalizeRateLimitPath(p string) string {
+	if p == "" {
+		return ""
+	}
+	p = strings.TrimPrefix(p, "/")
+	p = strings.TrimPrefix(p, "api/v2")
+	return "/" + strings.TrimPrefix(p, "/")
+}
+
+// defaultRateLimitBackoff holds back a bucket after Shopee reported it over
+// the limit without a Retry-After header
+const defaultRateLimitBackoff = time.Second
@@ -0,0 +1,143 @@
+package goshopee
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_RateLimiterLimitFor(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 10, Burst: 10})
+	l.SetLimit("/product/update_stock", 0, RateLimit{Rate: 2, Burst: 2})
+	l.SetLimit("", 42, RateLimit{Rate: 5, Burst: 5})
+	l.SetLimit("/api/v2/product/update_stock", 42, RateLimit{Rate: 1, Burst: 1})
+
+	cases := []struct {
+		key      RateLimitKey
+		expected float64
+	}{
+		{RateLimitKey{ShopID: 1, Path: "/order/get_order_list"}, 10},
+		{RateLimitKey{ShopID: 1, Path: "/product/update_stock"}, 2},
+		{RateLimitKey{ShopID: 42, Path: "/order/get_order_list"}, 5},
+		{RateLimitKey{ShopID: 42, Path: "/product/update_stock"}, 1},
+		{RateLimitKey{MerchantID: 42, Path: "/merchant/get_shop_list_by_merchant"}, 5},
+	}
+	for _, c := range cases {
+		if limit := l.limitFor(c.key); limit.Rate != c.expected {
+			t.Errorf("limitFor(%s) returned rate %v, expected %v", c.key, limit.Rate, c.expected)
+		}
+	}
+}
+
+func Test_RateLimiterReserve(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	l := NewRateLimiter(RateLimit{Rate: 2, Burst: 2})
+	l.now = func() time.Time { return now }
+
+	key := RateLimitKey{ShopID: shopID, Path: "/product/update_stock"}
+	for i, expected := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		if wait := l.reserve(key); wait != expected {
+			t.Errorf("reserve %d waits %s, expected %s", i, wait, expected)
+		}
+	}
+
+	now = now.Add(2 * time.Second)
+	if wait := l.reserve(key); wait != 0 {
+		t.Errorf("reserve after refill waits %s, expected 0", wait)
+	}
+
+	l.rateLimited(key, 3*time.Second)
+	if wait := l.reserve(key); wait != 3*time.Second {
+		t.Errorf("reserve after rate limited waits %s, expected 3s", wait)
+	}
+
+	stats := l.Stats()
+	if len(stats) != 1 || stats[0].Requests != 6 || stats[0].Throttled != 3 || stats[0].RateLimited != 1 {
+		t.Errorf("Stats returned %+v", stats)
+	}
+}
+
+func Test_RateLimiterWaitCancelled(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1})
+	key := RateLimitKey{ShopID: shopID, Path: "/product/update_stock"}
+	l.Wait(context.Background(), key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, key); err != context.DeadlineExceeded {
+		t.Errorf("Wait returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func Test_ClientRateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter := NewRateLimiter(RateLimit{Rate: 1000, Burst: 10})
+	c := NewClient(app, WithRateLimiter(limiter))
+	httpmock.ActivateNonDefault(c.Client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_profile_resp.json")))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_shop_info", app.APIURL),
+		httpmock.NewStringResponder(200, `{"error":"error_rate_limit","message":"too many requests"}`))
+
+	for _, sid := range []uint64{1, 2, 2} {
+		if _, err := c.Shop.GetProfile(sid, accessToken); err != nil {
+			t.Errorf("Shop.GetProfile error: %s", err)
+		}
+	}
+
+	var info CallInfo
+	_, err := c.Shop.GetShopInfoWithContext(WithCallInfo(context.Background(), &info), 2, accessToken)
+	if _, ok := err.(RateLimitError); !ok {
+		t.Errorf("Shop.GetShopInfo returned %#v, expected a RateLimitError", err)
+	}
+	if info.RateLimit.BucketSize != 10 || info.RateLimit.RequestCount != 1 || info.RateLimit.RetryAfterSeconds <= 0 {
+		t.Errorf("CallInfo.RateLimit is %+v", info.RateLimit)
+	}
+
+	sc := c.WithShop(2, accessToken)
+	if _, ok := sc.Get("/shop/get_shop_info", nil, nil).(RateLimitError); !ok {
+		t.Errorf("Get returned no RateLimitError")
+	}
+	if sc.RateLimits.BucketSize != 10 || sc.RateLimits.RetryAfterSeconds <= 0 {
+		t.Errorf("RateLimits returned %+v", sc.RateLimits)
+	}
+
+	requests := map[string]int64{}
+	for _, s := range limiter.Stats() {
+		requests[s.Key.String()] = s.Requests
+	}
+	expected := map[string]int64{
+		fmt.Sprintf("%d/shop:1/shop/get_profile", app.PartnerID):   1,
+		fmt.Sprintf("%d/shop:2/shop/get_profile", app.PartnerID):   2,
+		fmt.Sprintf("%d/shop:2/shop/get_shop_info", app.PartnerID): 2,
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("Stats returned %v, expected %v", requests, expected)
+	}
+}
+
+func Test_CallInfoRetryAfter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c := NewClient(app)
+	httpmock.ActivateNonDefault(c.Client)
+	resp := httpmock.NewStringResponse(429, `{"error":"error_rate_limit","message":"too many requests"}`)
+	resp.Header.Set("Retry-After", "3")
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_shop_info", app.APIURL),
+		httpmock.ResponderFromResponse(resp))
+
+	var info CallInfo
+	if _, err := c.Shop.GetShopInfoWithContext(WithCallInfo(context.Background(), &info), shopID, accessToken); err == nil {
+		t.Fatal("Shop.GetShopInfo returned no error")
+	}
+	if info.RateLimit.RetryAfterSeconds != 3 {
+		t.Errorf("CallInfo.RateLimit is %+v, expected RetryAfterSeconds 3", info.RateLimit)
+	}
+}
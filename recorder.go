@@ -0,0 +1,280 @@
+package goshopee
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNotRecorded is returned by a replaying Recorder for a request missing
+// from its cassette
+var ErrNotRecorded = errors.New("request not recorded")
+
+// RecorderMode tells a Recorder where the responses come from
+type RecorderMode int
+
+const (
+	// RecordMode sends requests to Shopee and records them
+	RecordMode RecorderMode = iota
+	// ReplayMode answers requests from the cassette, without network
+	ReplayMode
+)
+
+// volatile query parameters, left out of the recorded requests because they
+// change on every call
+var volatileParams = []string{"timestamp", "sign"}
+
+// Cassette is a recorded session, saved as JSON
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response it got
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request with timestamp and sign removed and secrets
+// scrubbed. Query is encoded with the parameters sorted.
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response with secrets and buyer data scrubbed
+type RecordedResponse struct {
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// Recorder is an http.RoundTripper recording Shopee traffic to a cassette
+// file, or replaying it in tests:
+//
+//	rec, err := goshopee.NewRecorder("testdata/orders.json", goshopee.ReplayMode)
+//	client := goshopee.NewClient(app, goshopee.WithTransport(rec))
+//
+// Requests are matched on method, path, query and body, ignoring timestamp
+// and sign, and replayed in the order they were recorded.
+type Recorder struct {
+	Mode RecorderMode
+
+	// Transport sends the requests in RecordMode, http.DefaultTransport
+	// when nil
+	Transport http.RoundTripper
+
+	// Redactor scrubs the cassette, DefaultRedactor when nil. Scrubbed
+	// fields keep their JSON types, see Redactor.ScrubBody.
+	Redactor *Redactor
+
+	path     string
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a recorder saving to, or replaying from, the cassette
+// file path
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{Mode: mode, path: path}
+	if mode == RecordMode {
+		return r, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &r.cassette); err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+func (r *Recorder) redactor() *Redactor {
+	if r.Redactor == nil {
+		return DefaultRedactor()
+	}
+	return r.Redactor
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body = readBody(&req.Body)
+	}
+	rr := r.recordRequest(req, body)
+
+	if r.Mode == ReplayMode {
+		return r.replay(req, rr)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody := readBody(&resp.Body)
+	header := resp.Header.Clone()
+	header.Del("Date")
+	header.Del("Set-Cookie")
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: rr,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: header,
+			Body:   r.recordBody(respBody),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, rr RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !rr.matches(in.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		body := []byte(in.Response.Body)
+		var s string
+		if json.Unmarshal(body, &s) == nil {
+			// not a JSON response, recorded as a string
+			body = []byte(s)
+		}
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s?%s", ErrNotRecorded, rr.Method, rr.Path, rr.Query)
+}
+
+// recordRequest normalizes req the way it is saved in the cassette
+func (r *Recorder) recordRequest(req *http.Request, body []byte) RecordedRequest {
+	q := req.URL.Query()
+	for _, p := range volatileParams {
+		q.Del(p)
+	}
+	redactor := r.redactor()
+	for k := range q {
+		if redactor.match(redactor.QueryParams, k) {
+			q.Set(k, redacted)
+		}
+	}
+
+	rr := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  q.Encode(),
+	}
+	if len(body) > 0 && json.Valid(body) {
+		// multipart uploads are matched without body, their boundary is random
+		rr.Body = redactor.ScrubBody(body)
+	}
+	return rr
+}
+
+// recordBody scrubs a response body, recording it as a string if it is not
+// JSON
+func (r *Recorder) recordBody(b []byte) json.RawMessage {
+	if !json.Valid(b) {
+		s, _ := json.Marshal(string(b))
+		return s
+	}
+	return r.redactor().ScrubBody(b)
+}
+
+func (rr RecordedRequest) matches(o RecordedRequest) bool {
+	if rr.Method != o.Method || rr.Path != o.Path || rr.Query != o.Query {
+		return false
+	}
+	return bytes.Equal(compactJSON(rr.Body), compactJSON(o.Body))
+}
+
+func compactJSON(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return b
+	}
+	out, _ := json.Marshal(v)
+	return out
+}
+
+// Cassette returns the recorded interactions
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes the cassette to the file of the recorder
+func (r *Recorder) Save() error {
+	b, err := json.MarshalIndent(r.Cassette(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, b, 0644)
+}
+
+// ExportFixtures writes the last recorded response body of every api to
+// dir, named like the fixtures of this package, e.g.
+// get_order_detail_resp.json for /api/v2/order/get_order_detail
+func (r *Recorder) ExportFixtures(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, in := range r.Cassette().Interactions {
+		var out bytes.Buffer
+		if err := json.Indent(&out, in.Response.Body, "", "  "); err != nil {
+			return fmt.Errorf("%s: %w", in.Request.Path, err)
+		}
+		name := path.Base(strings.TrimSuffix(in.Request.Path, "/")) + "_resp.json"
+		if err := ioutil.WriteFile(filepath.Join(dir, name), out.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package goshopee
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/passwind/go-shopee-v2/shopeetest"
+)
+
+func Test_RecorderRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	cassette := filepath.Join(dir, "cassettes", "stock.json")
+
+	srv := shopeetest.NewServer(12345678, "hush")
+	srv.AddShop(&shopeetest.Shop{ShopID: shopID, Name: "sandbox", Region: "SG"})
+	item := &shopeetest.Item{Name: "shirt", Price: 9.9, Stock: 1}
+	srv.AddItem(shopID, item)
+	tok := srv.IssueToken(shopID)
+	sandbox := App{PartnerID: srv.PartnerID, PartnerKey: srv.PartnerKey, APIURL: srv.URL}
+
+	rec, err := NewRecorder(cassette, RecordMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(sandbox, WithTransport(rec))
+	stock := UpdateStockRequest{ItemID: item.ItemID, StockList: []UpdateStockRequestData{{NormalStock: 5}}}
+	if _, err := c.Product.UpdateStock(shopID, stock, tok); err != nil {
+		t.Fatalf("Product.UpdateStock error: %s", err)
+	}
+	if _, err := c.Product.GetItemBaseInfo(shopID, []uint64{item.ItemID}, tok); err != nil {
+		t.Fatalf("Product.GetItemBaseInfo error: %s", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	b, _ := ioutil.ReadFile(cassette)
+	for _, s := range []string{tok, "timestamp", "sign="} {
+		if strings.Contains(string(b), s) {
+			t.Errorf("cassette contains %q:\n%s", s, b)
+		}
+	}
+
+	rep, err := NewRecorder(cassette, ReplayMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = NewClient(sandbox, WithTransport(rep))
+	res, err := c.Product.UpdateStock(shopID, stock, "another token")
+	if err != nil {
+		t.Fatalf("replayed Product.UpdateStock error: %s", err)
+	}
+	if len(res.Response.SuccessList) != 1 || res.Response.SuccessList[0].NormalStock != 5 {
+		t.Errorf("replayed Product.UpdateStock returned %+v", res.Response)
+	}
+	info, err := c.Product.GetItemBaseInfo(shopID, []uint64{item.ItemID}, tok)
+	if err != nil {
+		t.Fatalf("replayed Product.GetItemBaseInfo error: %s", err)
+	}
+	if got := info.Response.ItemList[0].StockInfo[0].NormalStock; got != 5 {
+		t.Errorf("replayed stock is %d, expected 5", got)
+	}
+
+	// every interaction is replayed once
+	_, err = c.Product.GetItemBaseInfo(shopID, []uint64{item.ItemID}, tok)
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Product.GetItemBaseInfo returned %v, expected ErrNotRecorded", err)
+	}
+
+	if err := rep.ExportFixtures(dir); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "get_item_base_info_resp.json")); err != nil || !strings.Contains(string(b), `"item_name": "shirt"`) {
+		t.Errorf("exported fixture %s, %v", b, err)
+	}
+}
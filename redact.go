@@ -0,0 +1,151 @@
+package goshopee
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Redactor hides secrets and buyer data from logged requests and responses.
+// Names are matched case insensitively. A field matching Fields is replaced
+// whole, objects included, wherever it is nested in a JSON body.
+type Redactor struct {
+	// query parameters, e.g. access_token and sign
+	QueryParams []string
+	// JSON body fields, e.g. phone
+	Fields []string
+}
+
+// DefaultRedactor hides tokens, signatures, authorization codes and buyer
+// addresses and contact details.
+func DefaultRedactor() *Redactor {
+	return &Redactor{
+		QueryParams: []string{"access_token", "sign", "code", "refresh_token"},
+		Fields: []string{
+			"access_token", "refresh_token", "code", "sign",
+			"recipient_address", "phone", "full_address", "buyer_cpf_id",
+			"credit_card_number", "dropshipper", "dropshipper_phone", "buyer_username",
+			"buyer_user_name", "buyer_name", "email", "username",
+		},
+	}
+}
+
+func (r *Redactor) match(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactURL returns u as a string with the values of QueryParams hidden
+func (r *Redactor) RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	if r == nil || u.RawQuery == "" {
+		return u.String()
+	}
+
+	q := u.Query()
+	for k := range q {
+		if r.match(r.QueryParams, k) {
+			q.Set(k, redacted)
+		}
+	}
+	cu := *u
+	cu.RawQuery = q.Encode()
+	return cu.String()
+}
+
+// RedactBody returns the JSON body b with the values of Fields hidden. Bodies
+// that are not JSON are returned unchanged.
+func (r *Redactor) RedactBody(b []byte) []byte {
+	if r == nil || len(r.Fields) == 0 || len(b) == 0 {
+		return b
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return b
+	}
+
+	out, err := json.Marshal(r.redactValue(v))
+	if err != nil {
+		return b
+	}
+	return out
+}
+
+func (r *Redactor) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range v {
+			if r.match(r.Fields, k) {
+				v[k] = redacted
+			} else {
+				v[k] = r.redactValue(fv)
+			}
+		}
+	case []interface{}:
+		for i, ev := range v {
+			v[i] = r.redactValue(ev)
+		}
+	}
+	return v
+}
+
+// ScrubBody is RedactBody keeping the JSON types, so the scrubbed body still
+// decodes into the response structs: strings of matching fields become
+// [REDACTED], numbers 0 and booleans false, objects and arrays are scrubbed
+// through.
+func (r *Redactor) ScrubBody(b []byte) []byte {
+	if r == nil || len(r.Fields) == 0 || len(b) == 0 {
+		return b
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return b
+	}
+
+	out, err := json.Marshal(r.scrubValue(v, false))
+	if err != nil {
+		return b
+	}
+	return out
+}
+
+func (r *Redactor) scrubValue(v interface{}, hide bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range v {
+			v[k] = r.scrubValue(fv, hide || r.match(r.Fields, k))
+		}
+	case []interface{}:
+		for i, ev := range v {
+			v[i] = r.scrubValue(ev, hide)
+		}
+	case string:
+		if hide {
+			return redacted
+		}
+	case json.Number:
+		if hide {
+			return json.Number("0")
+		}
+	case bool:
+		if hide {
+			return false
+		}
+	}
+	return v
+}
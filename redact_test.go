@@ -0,0 +1,75 @@
+package goshopee
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_RedactURL(t *testing.T) {
+	u, _ := url.Parse("https://partner.shopeemobile.com/api/v2/shop/get_profile?access_token=secret&partner_id=1&shop_id=2&sign=abc&timestamp=3")
+
+	res := DefaultRedactor().RedactURL(u)
+	if strings.Contains(res, "secret") || strings.Contains(res, "abc") {
+		t.Errorf("RedactURL returned %s, expected token and sign hidden", res)
+	}
+	if !strings.Contains(res, "shop_id=2") {
+		t.Errorf("RedactURL returned %s, expected shop_id kept", res)
+	}
+	if u.Query().Get("access_token") != "secret" {
+		t.Errorf("RedactURL modified the url")
+	}
+}
+
+func Test_RedactBody(t *testing.T) {
+	body := `{"response":{"order_list":[{"order_sn":"SN1","total_amount":12345678901234567,` +
+		`"recipient_address":{"name":"Alice","phone":"6512345678","full_address":"1 Main St"},` +
+		`"Buyer_Username":"alice"}]},"access_token":"s3cr3t"}`
+
+	res := string(DefaultRedactor().RedactBody([]byte(body)))
+	for _, secret := range []string{"Alice", "6512345678", "1 Main St", "alice", "s3cr3t"} {
+		if strings.Contains(res, secret) {
+			t.Errorf("RedactBody kept %q in %s", secret, res)
+		}
+	}
+	if !strings.Contains(res, `"order_sn":"SN1"`) || !strings.Contains(res, "12345678901234567") {
+		t.Errorf("RedactBody returned %s, expected order_sn and amount kept", res)
+	}
+
+	ret := `{"response":{"return_sn":"R1","user":{"username":"bob","email":"bob@example.com","portrait":"p.jpg"}}}`
+	res = string(DefaultRedactor().RedactBody([]byte(ret)))
+	if strings.Contains(res, "bob") || !strings.Contains(res, `"return_sn":"R1"`) {
+		t.Errorf("RedactBody returned %s, expected the return user hidden", res)
+	}
+
+	if res := DefaultRedactor().RedactBody([]byte("not json")); string(res) != "not json" {
+		t.Errorf("RedactBody returned %s for a non json body", res)
+	}
+}
+
+func Test_ScrubBody(t *testing.T) {
+	body := `{"response":{"order_list":[{"order_sn":"SN1","recipient_address":{"name":"Alice","zipcode":"123"},` +
+		`"credit_card_number":"4111","item_list":[{"item_id":1}]}]},"access_token":"s3cr3t"}`
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+		Response    struct {
+			OrderList []Order `json:"order_list"`
+		} `json:"response"`
+	}
+	b := DefaultRedactor().ScrubBody([]byte(body))
+	if err := json.Unmarshal(b, &res); err != nil {
+		t.Fatalf("ScrubBody returned %s, which does not decode: %s", b, err)
+	}
+	o := res.Response.OrderList[0]
+	if res.AccessToken != "[REDACTED]" || o.RecipientAddress.Name != "[REDACTED]" || o.RecipientAddress.Zipcode != "[REDACTED]" {
+		t.Errorf("ScrubBody kept secrets in %s", b)
+	}
+	if o.OrderSN != "SN1" || o.ItemList[0].ItemID != 1 {
+		t.Errorf("ScrubBody returned %s, expected order_sn and item_list kept", b)
+	}
+	if strings.Contains(string(b), "4111") {
+		t.Errorf("ScrubBody kept the card number in %s", b)
+	}
+}
@@ -0,0 +1,181 @@
+package goshopee
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request is sent again. Retry is
+// called after attempt (counting from 1) failed with err and returns how
+// long to wait before the next attempt, or false to give up and return err.
+type RetryPolicy interface {
+	Retry(req *http.Request, attempt int, err error) (time.Duration, bool)
+}
+
+// RetryEvent describes a retry about to happen, see BackoffPolicy.OnRetry
+type RetryEvent struct {
+	Request *http.Request
+	Attempt int // the attempt that failed
+	Err     error
+	Wait    time.Duration
+}
+
+// BackoffPolicy retries with exponential backoff. The n-th retry waits
+// BaseDelay*2^(n-1), at most MaxDelay, or the Retry-After of a rate limited
+// response when Shopee sends one. Jitter randomly shortens each wait by up
+// to that fraction, so clients that failed together do not retry together.
+//
+// Only requests failing with one of Classes are retried. Requests that are
+// not idempotent, see IsIdempotent, are only retried when rate limited,
+// since Shopee did not process them.
+type BackoffPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Jitter      float64
+
+	// Error classes to retry, defaults to ErrRateLimited and ErrServer
+	Classes []error
+
+	// OnRetry, if set, is called before waiting for each retry
+	OnRetry func(RetryEvent)
+}
+
+const (
+	defaultRetryBaseDelay = 200 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+	defaultRetryJitter    = 0.2
+)
+
+// NewBackoffPolicy returns a BackoffPolicy making up to maxAttempts attempts
+// with the default delays
+func NewBackoffPolicy(maxAttempts int) *BackoffPolicy {
+	return &BackoffPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+		Jitter:      defaultRetryJitter,
+	}
+}
+
+func (p *BackoffPolicy) Retry(req *http.Request, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !p.retryClass(err) {
+		return 0, false
+	}
+	if !errors.Is(err, ErrRateLimited) && !IsIdempotent(req) {
+		return 0, false
+	}
+
+	wait := p.backoff(attempt)
+	var rateLimitErr RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+		wait = time.Duration(rateLimitErr.RetryAfter) * time.Second
+	}
+
+	if p.OnRetry != nil {
+		p.OnRetry(RetryEvent{Request: req, Attempt: attempt, Err: err, Wait: wait})
+	}
+	return wait, true
+}
+
+func (p *BackoffPolicy) retryClass(err error) bool {
+	if p.Classes == nil {
+		return isRetryable(err)
+	}
+	for _, class := range p.Classes {
+		if errors.Is(err, class) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *BackoffPolicy) backoff(attempt int) time.Duration {
+	wait := p.BaseDelay
+	for i := 1; i < attempt && wait < p.MaxDelay; i++ {
+		wait *= 2
+	}
+	if p.MaxDelay > 0 && wait > p.MaxDelay {
+		wait = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		wait -= time.Duration(rand.Float64() * p.Jitter * float64(wait))
+	}
+	return wait
+}
+
+// idempotentPaths are the POST apis setting a state, which give the same
+// result when sent twice. Every other POST may act twice, e.g. cancel an
+// order or dispute a return again, and is not retried on server errors
+// unless the caller opts in with RetryNonIdempotent.
+var idempotentPaths = map[string]bool{
+	"/product/update_stock":          true,
+	"/product/update_price":          true,
+	"/product/update_item":           true,
+	"/product/update_model":          true,
+	"/product/update_size_chart":     true,
+	"/product/update_tier_variation": true,
+	"/product/unlist_item":           true,
+	"/order/set_note":                true,
+	"/discount/update_discount_item": true,
+	"/push/set_app_push_config":      true,
+}
+
+type retryNonIdempotentKey struct{}
+
+// RetryNonIdempotent returns a copy of ctx allowing the requests made with it
+// to be retried even when they are not idempotent, e.g. because the caller
+// checks for duplicates itself.
+func RetryNonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryNonIdempotentKey{}, true)
+}
+
+// IsIdempotent reports whether req may safely be sent again after a failure.
+// GET requests and the POST apis setting a state, like UpdateStock,
+// UpdatePrice or SetNote, are. Other POST apis, like ShipOrder, CancelOrder
+// or AddItem, are not, unless req was made with a context from
+// RetryNonIdempotent.
+func IsIdempotent(req *http.Request) bool {
+	if req.Method == http.MethodGet {
+		return true
+	}
+	if ok, _ := req.Context().Value(retryNonIdempotentKey{}).(bool); ok {
+		return true
+	}
+	return idempotentPaths[strings.TrimPrefix(req.URL.Path, "/api/v2")]
+}
+
+// CallInfo receives details about the calls made with a context from
+// WithCallInfo.
+type CallInfo struct {
+	// Attempts counts the requests sent, retries and token refreshes
+	// included
+	Attempts int
+	// RateLimit is the state of the rate limiter bucket after the last
+	// attempt. RetryAfterSeconds comes from the Retry-After header when
+	// Shopee rate limited the call.
+	RateLimit RateLimitInfo
+}
+
+type callInfoKey struct{}
+
+// WithCallInfo returns a copy of ctx filling info with the details of the
+// calls made with it:
+//
+//	var info goshopee.CallInfo
+//	res, err := client.Order.GetOrderListWithContext(goshopee.WithCallInfo(ctx, &info), sid, req, tok)
+//	log.Printf("%d attempts", info.Attempts)
+//
+// info must not be shared by concurrent calls.
+func WithCallInfo(ctx context.Context, info *CallInfo) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+func callInfoFrom(ctx context.Context) *CallInfo {
+	info, _ := ctx.Value(callInfoKey{}).(*CallInfo)
+	return info
+}
@@ -0,0 +1,185 @@
+package goshopee
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_BackoffPolicyDelays(t *testing.T) {
+	p := &BackoffPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	req, _ := http.NewRequest("GET", "https://example.com/api/v2/shop/get_profile", nil)
+
+	for attempt, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second, 9: time.Second} {
+		wait, retry := p.Retry(req, attempt, ResponseError{Status: 503})
+		if !retry || wait != expected {
+			t.Errorf("attempt %d waits %s (retry %v), expected %s", attempt, wait, retry, expected)
+		}
+	}
+
+	if _, retry := p.Retry(req, 10, ResponseError{Status: 503}); retry {
+		t.Errorf("retried after MaxAttempts")
+	}
+	if _, retry := p.Retry(req, 1, ResponseError{Status: 200, Code: "error_param"}); retry {
+		t.Errorf("retried a validation error")
+	}
+	if wait, _ := p.Retry(req, 1, RateLimitError{ResponseError: ResponseError{Status: 429}, RetryAfter: 3}); wait != 3*time.Second {
+		t.Errorf("rate limited attempt waits %s, expected Retry-After 3s", wait)
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		if wait, _ := p.Retry(req, 2, ResponseError{Status: 503}); wait < 100*time.Millisecond || wait > 200*time.Millisecond {
+			t.Errorf("jittered wait %s out of [100ms, 200ms]", wait)
+		}
+	}
+}
+
+func Test_BackoffPolicyClasses(t *testing.T) {
+	p := &BackoffPolicy{MaxAttempts: 3, Classes: []error{ErrRateLimited}}
+	req, _ := http.NewRequest("GET", "https://example.com/api/v2/shop/get_profile", nil)
+
+	if _, retry := p.Retry(req, 1, ResponseError{Status: 503}); retry {
+		t.Errorf("retried a server error not in Classes")
+	}
+	if _, retry := p.Retry(req, 1, ResponseError{Status: 429}); !retry {
+		t.Errorf("did not retry a rate limited error in Classes")
+	}
+}
+
+func setupRetryClient(p RetryPolicy) *Client {
+	setup()
+	c := NewClient(app, WithRetryPolicy(p))
+	httpmock.ActivateNonDefault(c.Client)
+	return c
+}
+
+func Test_RetryIdempotency(t *testing.T) {
+	var events []RetryEvent
+	c := setupRetryClient(&BackoffPolicy{MaxAttempts: 3, OnRetry: func(e RetryEvent) { events = append(events, e) }})
+	defer teardown()
+
+	var bodies []string
+	respBody := `{"error":"error_server","message":"busy"}`
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/logistics/ship_order", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(b))
+			return httpmock.NewStringResponse(200, respBody), nil
+		})
+
+	data := map[string]interface{}{"order_sn": "201218V2Y6E59M"}
+	sc := c.WithShop(shopID, accessToken)
+
+	var info CallInfo
+	sc.PostWithContext(WithCallInfo(context.Background(), &info), "/logistics/ship_order", data, nil)
+	if info.Attempts != 1 || len(events) != 0 {
+		t.Errorf("ShipOrder sent %d times, expected no retry", info.Attempts)
+	}
+
+	info = CallInfo{}
+	sc.PostWithContext(WithCallInfo(RetryNonIdempotent(context.Background()), &info), "/logistics/ship_order", data, nil)
+	if info.Attempts != 3 || len(events) != 2 {
+		t.Errorf("opted in ShipOrder sent %d times, %d retry events, expected 3 and 2", info.Attempts, len(events))
+	}
+	if bodies[2] != bodies[1] || bodies[2] == "" {
+		t.Errorf("retried body %q, expected %q", bodies[2], bodies[1])
+	}
+
+	respBody = `{"error":"error_rate_limit","message":"too many requests"}`
+	info = CallInfo{}
+	sc.PostWithContext(WithCallInfo(context.Background(), &info), "/logistics/ship_order", data, nil)
+	if info.Attempts != 3 {
+		t.Errorf("rate limited ShipOrder sent %d times, expected 3", info.Attempts)
+	}
+}
+
+func Test_IsIdempotent(t *testing.T) {
+	cases := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{"GET", "/api/v2/order/get_order_detail", true},
+		{"POST", "/api/v2/product/update_stock", true},
+		{"POST", "/api/v2/order/set_note", true},
+		{"POST", "/api/v2/order/cancel_order", false},
+		{"POST", "/api/v2/returns/dispute", false},
+		{"POST", "/api/v2/media_space/upload_image", false},
+		{"POST", "/api/v2/product/some_new_api", false},
+	}
+	for _, c := range cases {
+		req, _ := http.NewRequest(c.method, app.APIURL+c.path, nil)
+		if IsIdempotent(req) != c.expected {
+			t.Errorf("IsIdempotent(%s %s) returned %v", c.method, c.path, !c.expected)
+		}
+	}
+}
+
+func Test_WithRetryCountsRetries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c := NewClient(app, WithRetry(1))
+	httpmock.ActivateNonDefault(c.Client)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		httpmock.NewStringResponder(503, `{"error":"error_server","message":"busy"}`))
+
+	var info CallInfo
+	c.Shop.GetProfileWithContext(WithCallInfo(context.Background(), &info), shopID, accessToken)
+	if info.Attempts != 2 {
+		t.Errorf("WithRetry(1) sent %d requests, expected 2", info.Attempts)
+	}
+}
+
+func Test_RetrySignsAgain(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Unix(1655714431, 0)
+	signer := NewSigner(app.PartnerID, app.PartnerKey)
+	signer.Now = func() time.Time {
+		now = now.Add(10 * time.Minute)
+		return now
+	}
+	policy := NewBackoffPolicy(2)
+	policy.BaseDelay = time.Millisecond
+	c := NewClient(app, WithSigner(signer), WithRetryPolicy(policy))
+	httpmock.ActivateNonDefault(c.Client)
+
+	var queries []url.Values
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.Query())
+			if len(queries) == 1 {
+				return httpmock.NewStringResponse(503, `{"error":"error_server","message":"busy"}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_profile_resp.json")), nil
+		})
+
+	if _, err := c.Shop.GetProfile(shopID, accessToken); err != nil {
+		t.Fatalf("Shop.GetProfile error: %s", err)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("%d attempts, expected 2", len(queries))
+	}
+	first, second := queries[0], queries[1]
+	if len(second["timestamp"]) != 1 || len(second["sign"]) != 1 || len(second["access_token"]) != 1 {
+		t.Errorf("retry sent %v", second)
+	}
+	if first.Get("timestamp") == second.Get("timestamp") {
+		t.Errorf("retry reused timestamp %s", first.Get("timestamp"))
+	}
+	ts, _ := strconv.ParseInt(second.Get("timestamp"), 10, 64)
+	expected := signer.Sign(signer.ShopBaseString("/api/v2/shop/get_profile", ts, accessToken, shopID))
+	if second.Get("sign") != expected {
+		t.Errorf("retry signed %s, expected %s", second.Get("sign"), expected)
+	}
+}
@@ -0,0 +1,391 @@
+package goshopee
+
+import "context"
+
+// https://open.shopee.com/documents/v2/v2.returns.get_return_list?module=102&type=1
+type ReturnsService interface {
+	GetReturnList(uint64, GetReturnListRequest, string) (*GetReturnListResponse, error)
+	GetReturnListWithContext(context.Context, uint64, GetReturnListRequest, string) (*GetReturnListResponse, error)
+	GetReturnDetail(uint64, string, string) (*GetReturnDetailResponse, error)
+	GetReturnDetailWithContext(context.Context, uint64, string, string) (*GetReturnDetailResponse, error)
+	Confirm(uint64, string, string) (*ConfirmReturnResponse, error)
+	ConfirmWithContext(context.Context, uint64, string, string) (*ConfirmReturnResponse, error)
+	Dispute(uint64, DisputeReturnRequest, string) (*DisputeReturnResponse, error)
+	DisputeWithContext(context.Context, uint64, DisputeReturnRequest, string) (*DisputeReturnResponse, error)
+	Offer(uint64, OfferReturnRequest, string) (*OfferReturnResponse, error)
+	OfferWithContext(context.Context, uint64, OfferReturnRequest, string) (*OfferReturnResponse, error)
+	AcceptOffer(uint64, string, string) (*AcceptReturnOfferResponse, error)
+	AcceptOfferWithContext(context.Context, uint64, string, string) (*AcceptReturnOfferResponse, error)
+	GetAvailableSolutions(uint64, string, string) (*GetAvailableSolutionsResponse, error)
+	GetAvailableSolutionsWithContext(context.Context, uint64, string, string) (*GetAvailableSolutionsResponse, error)
+	UploadProof(uint64, UploadReturnProofRequest, string) (*UploadReturnProofResponse, error)
+	UploadProofWithContext(context.Context, uint64, UploadReturnProofRequest, string) (*UploadReturnProofResponse, error)
+}
+
+type ReturnsServiceOp struct {
+	client *Client
+}
+
+// ReturnStatus is the status of a return request
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested     ReturnStatus = "REQUESTED"
+	ReturnStatusAccepted      ReturnStatus = "ACCEPTED"
+	ReturnStatusCancelled     ReturnStatus = "CANCELLED"
+	ReturnStatusJudging       ReturnStatus = "JUDGING"
+	ReturnStatusClosed        ReturnStatus = "CLOSED"
+	ReturnStatusProcessing    ReturnStatus = "PROCESSING"
+	ReturnStatusSellerDispute ReturnStatus = "SELLER_DISPUTE"
+)
+
+// ReturnReason is why the buyer asks for a return
+type ReturnReason string
+
+const (
+	ReturnReasonNone                 ReturnReason = "NONE"
+	ReturnReasonNotReceipt           ReturnReason = "NOT_RECEIPT"
+	ReturnReasonWrongItem            ReturnReason = "WRONG_ITEM"
+	ReturnReasonItemDamaged          ReturnReason = "ITEM_DAMAGED"
+	ReturnReasonDifferentDescription ReturnReason = "DIFFERENT_DESCRIPTION"
+	ReturnReasonMutualAgree          ReturnReason = "MUAL_AGREE" // sic
+	ReturnReasonOther                ReturnReason = "OTHER"
+	ReturnReasonChangeMind           ReturnReason = "CHANGE_MIND"
+	ReturnReasonItemMissing          ReturnReason = "ITEM_MISSING"
+	ReturnReasonExpectationFailed    ReturnReason = "EXPECTATION_FAILED"
+	ReturnReasonItemFake             ReturnReason = "ITEM_FAKE"
+	ReturnReasonPhysicalDamage       ReturnReason = "PHYSICAL_DMG"
+	ReturnReasonFunctionalDamage     ReturnReason = "FUNCTIONAL_DMG"
+)
+
+// ReturnNegotiationStatus is the state of the refund negotiation with the buyer
+type ReturnNegotiationStatus string
+
+const (
+	ReturnNegotiationPendingRespond      ReturnNegotiationStatus = "PENDING_RESPOND"
+	ReturnNegotiationPendingBuyerRespond ReturnNegotiationStatus = "PENDING_BUYER_RESPOND"
+	ReturnNegotiationTerminated          ReturnNegotiationStatus = "TERMINATED"
+)
+
+// ReturnDisputeReason is why the seller disputes a return. The reasons
+// allowed for a return are listed by get_return_dispute_reason.
+type ReturnDisputeReason int
+
+const (
+	ReturnDisputeNonReceipt  ReturnDisputeReason = 1
+	ReturnDisputeOther       ReturnDisputeReason = 2
+	ReturnDisputeNotReceived ReturnDisputeReason = 3
+	ReturnDisputeUnknown     ReturnDisputeReason = 4
+)
+
+// Solutions the seller may offer for a return
+const (
+	ReturnSolutionReturnRefund = 0
+	ReturnSolutionRefund       = 1
+)
+
+type Return struct {
+	ReturnSN             string                `json:"return_sn"`
+	OrderSN              string                `json:"order_sn"`
+	Status               ReturnStatus          `json:"status"`
+	Reason               ReturnReason          `json:"reason"`
+	TextReason           string                `json:"text_reason"`
+	Image                []string              `json:"image"`
+	Currency             string                `json:"currency"`
+	RefundAmount         float64               `json:"refund_amount"`
+	AmountBeforeDiscount float64               `json:"amount_before_discount"`
+	CreateTime           int64                 `json:"create_time"`
+	UpdateTime           int64                 `json:"update_time"`
+	DueDate              int64                 `json:"due_date"`
+	ReturnShipDueDate    int64                 `json:"return_ship_due_date"`
+	ReturnSellerDueDate  int64                 `json:"return_seller_due_date"`
+	TrackingNumber       string                `json:"tracking_number"`
+	NeedsLogistics       bool                  `json:"needs_logistics"`
+	LogisticsStatus      string                `json:"logistics_status"`
+	DisputeReason        []ReturnDisputeReason `json:"dispute_reason"`
+	DisputeTextReason    []string              `json:"dispute_text_reason"`
+	User                 ReturnUser            `json:"user"`
+	Item                 []ReturnItem          `json:"item"`
+	Negotiation          ReturnNegotiation     `json:"negotiation"`
+	SellerProof          ReturnSellerProof     `json:"seller_proof"`
+	ReturnSolution       int                   `json:"return_solution"`
+	ReturnRefundType     string                `json:"return_refund_type"`
+	IsSellerArrange      bool                  `json:"is_seller_arrange"`
+}
+
+type ReturnUser struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Portrait string `json:"portrait"`
+}
+
+type ReturnItem struct {
+	ItemID       uint64   `json:"item_id"`
+	ModelID      uint64   `json:"model_id"`
+	Name         string   `json:"name"`
+	Images       []string `json:"images"`
+	Amount       int      `json:"amount"`
+	ItemPrice    float64  `json:"item_price"`
+	RefundAmount float64  `json:"refund_amount"`
+	IsAddOnDeal  bool     `json:"is_add_on_deal"`
+	IsMainItem   bool     `json:"is_main_item"`
+	AddOnDealID  uint64   `json:"add_on_deal_id"`
+	ItemSKU      string   `json:"item_sku"`
+	VariationSKU string   `json:"variation_sku"`
+}
+
+type ReturnNegotiation struct {
+	NegotiationStatus  ReturnNegotiationStatus `json:"negotiation_status"`
+	LatestSolution     string                  `json:"latest_solution"`
+	LatestOfferAmount  float64                 `json:"latest_offer_amount"`
+	LatestOfferCreator string                  `json:"latest_offer_creator"`
+	CounterOfferChance int                     `json:"counter_offer_chance"`
+	OfferDueDate       int64                   `json:"offer_due_date"`
+}
+
+type ReturnSellerProof struct {
+	SellerProofStatus      string `json:"seller_proof_status"`
+	SellerEvidenceDeadline int64  `json:"seller_evidence_deadline"`
+}
+
+type GetReturnListRequest struct {
+	PageNo            int                     `url:"page_no"`
+	PageSize          int                     `url:"page_size"`
+	CreateTimeFrom    int64                   `url:"create_time_from,omitempty"`
+	CreateTimeTo      int64                   `url:"create_time_to,omitempty"`
+	UpdateTimeFrom    int64                   `url:"update_time_from,omitempty"`
+	UpdateTimeTo      int64                   `url:"update_time_to,omitempty"`
+	Status            ReturnStatus            `url:"status,omitempty"`
+	NegotiationStatus ReturnNegotiationStatus `url:"negotiation_status,omitempty"`
+}
+
+type GetReturnListResponse struct {
+	BaseResponse
+
+	Response GetReturnListResponseData `json:"response"`
+}
+
+type GetReturnListResponseData struct {
+	More   bool     `json:"more"`
+	Return []Return `json:"return"`
+}
+
+func (s *ReturnsServiceOp) GetReturnList(sid uint64, opt GetReturnListRequest, tok string) (*GetReturnListResponse, error) {
+	return s.GetReturnListWithContext(context.Background(), sid, opt, tok)
+}
+
+func (s *ReturnsServiceOp) GetReturnListWithContext(ctx context.Context, sid uint64, opt GetReturnListRequest, tok string) (*GetReturnListResponse, error) {
+	path := "/returns/get_return_list"
+
+	resp := new(GetReturnListResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+type GetReturnDetailRequest struct {
+	ReturnSN string `url:"return_sn"`
+}
+
+type GetReturnDetailResponse struct {
+	BaseResponse
+
+	Response Return `json:"response"`
+}
+
+func (s *ReturnsServiceOp) GetReturnDetail(sid uint64, returnSN, tok string) (*GetReturnDetailResponse, error) {
+	return s.GetReturnDetailWithContext(context.Background(), sid, returnSN, tok)
+}
+
+func (s *ReturnsServiceOp) GetReturnDetailWithContext(ctx context.Context, sid uint64, returnSN, tok string) (*GetReturnDetailResponse, error) {
+	path := "/returns/get_return_detail"
+
+	opt := GetReturnDetailRequest{
+		ReturnSN: returnSN,
+	}
+
+	resp := new(GetReturnDetailResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+type ConfirmReturnResponse struct {
+	BaseResponse
+
+	Response ReturnSNResponseData `json:"response"`
+}
+
+type ReturnSNResponseData struct {
+	ReturnSN string `json:"return_sn"`
+}
+
+// Confirm accepts the return and refunds the buyer
+func (s *ReturnsServiceOp) Confirm(sid uint64, returnSN, tok string) (*ConfirmReturnResponse, error) {
+	return s.ConfirmWithContext(context.Background(), sid, returnSN, tok)
+}
+
+func (s *ReturnsServiceOp) ConfirmWithContext(ctx context.Context, sid uint64, returnSN, tok string) (*ConfirmReturnResponse, error) {
+	path := "/returns/confirm"
+	req := map[string]interface{}{
+		"return_sn": returnSN,
+	}
+	resp := new(ConfirmReturnResponse)
+	err := s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+type DisputeReturnRequest struct {
+	ReturnSN string `json:"return_sn"`
+	Email    string `json:"email"`
+	// DisputeReason is one of the reasons returned by get_return_dispute_reason
+	DisputeReason     ReturnDisputeReason `json:"dispute_reason"`
+	DisputeTextReason string              `json:"dispute_text_reason"`
+	Images            []string            `json:"images,omitempty"`
+}
+
+type DisputeReturnResponse struct {
+	BaseResponse
+
+	Response DisputeReturnResponseData `json:"response"`
+}
+
+type DisputeReturnResponseData struct {
+	ReturnSN string `json:"return_sn"`
+	Msg      string `json:"msg"`
+}
+
+func (s *ReturnsServiceOp) Dispute(sid uint64, data DisputeReturnRequest, tok string) (*DisputeReturnResponse, error) {
+	return s.DisputeWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *ReturnsServiceOp) DisputeWithContext(ctx context.Context, sid uint64, data DisputeReturnRequest, tok string) (*DisputeReturnResponse, error) {
+	path := "/returns/dispute"
+	resp := new(DisputeReturnResponse)
+	req, err := StructToMap(data)
+	if err != nil {
+		return nil, err
+	}
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+type OfferReturnRequest struct {
+	ReturnSN string `json:"return_sn"`
+	// ProposedSolution is ReturnSolutionReturnRefund or ReturnSolutionRefund
+	ProposedSolution             int     `json:"proposed_solution"`
+	ProposedAdjustedRefundAmount float64 `json:"proposed_adjusted_refund_amount"`
+}
+
+type OfferReturnResponse struct {
+	BaseResponse
+
+	Response ReturnSNResponseData `json:"response"`
+}
+
+func (s *ReturnsServiceOp) Offer(sid uint64, data OfferReturnRequest, tok string) (*OfferReturnResponse, error) {
+	return s.OfferWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *ReturnsServiceOp) OfferWithContext(ctx context.Context, sid uint64, data OfferReturnRequest, tok string) (*OfferReturnResponse, error) {
+	path := "/returns/offer"
+	resp := new(OfferReturnResponse)
+	req, err := StructToMap(data)
+	if err != nil {
+		return nil, err
+	}
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+type AcceptReturnOfferResponse struct {
+	BaseResponse
+
+	Response ReturnSNResponseData `json:"response"`
+}
+
+// AcceptOffer accepts the latest solution offered by the buyer
+func (s *ReturnsServiceOp) AcceptOffer(sid uint64, returnSN, tok string) (*AcceptReturnOfferResponse, error) {
+	return s.AcceptOfferWithContext(context.Background(), sid, returnSN, tok)
+}
+
+func (s *ReturnsServiceOp) AcceptOfferWithContext(ctx context.Context, sid uint64, returnSN, tok string) (*AcceptReturnOfferResponse, error) {
+	path := "/returns/accept_offer"
+	req := map[string]interface{}{
+		"return_sn": returnSN,
+	}
+	resp := new(AcceptReturnOfferResponse)
+	err := s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
+
+type GetAvailableSolutionsRequest struct {
+	ReturnSN string `url:"return_sn"`
+}
+
+type GetAvailableSolutionsResponse struct {
+	BaseResponse
+
+	Response GetAvailableSolutionsResponseData `json:"response"`
+}
+
+type GetAvailableSolutionsResponseData struct {
+	Solution []ReturnSolution `json:"solution"`
+}
+
+type ReturnSolution struct {
+	Solution        int     `json:"solution"`
+	MaxRefundAmount float64 `json:"max_refund_amount"`
+}
+
+func (s *ReturnsServiceOp) GetAvailableSolutions(sid uint64, returnSN, tok string) (*GetAvailableSolutionsResponse, error) {
+	return s.GetAvailableSolutionsWithContext(context.Background(), sid, returnSN, tok)
+}
+
+func (s *ReturnsServiceOp) GetAvailableSolutionsWithContext(ctx context.Context, sid uint64, returnSN, tok string) (*GetAvailableSolutionsResponse, error) {
+	path := "/returns/get_available_solutions"
+
+	opt := GetAvailableSolutionsRequest{
+		ReturnSN: returnSN,
+	}
+
+	resp := new(GetAvailableSolutionsResponse)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, opt)
+	return resp, err
+}
+
+// Photo and video must be uploaded first, with MediaSpace and the returns
+// convert_image endpoint
+type UploadReturnProofRequest struct {
+	ReturnSN    string                   `json:"return_sn"`
+	Photo       []UploadReturnProofPhoto `json:"photo,omitempty"`
+	Video       []UploadReturnProofVideo `json:"video,omitempty"`
+	Description string                   `json:"description,omitempty"`
+}
+
+type UploadReturnProofPhoto struct {
+	Thumbnail string `json:"thumbnail"`
+	URL       string `json:"url"`
+}
+
+type UploadReturnProofVideo struct {
+	ThumbnailURL  string `json:"thumbnail_url"`
+	VideoUploadID string `json:"video_upload_id"`
+}
+
+type UploadReturnProofResponse struct {
+	BaseResponse
+}
+
+func (s *ReturnsServiceOp) UploadProof(sid uint64, data UploadReturnProofRequest, tok string) (*UploadReturnProofResponse, error) {
+	return s.UploadProofWithContext(context.Background(), sid, data, tok)
+}
+
+func (s *ReturnsServiceOp) UploadProofWithContext(ctx context.Context, sid uint64, data UploadReturnProofRequest, tok string) (*UploadReturnProofResponse, error) {
+	path := "/returns/upload_proof"
+	resp := new(UploadReturnProofResponse)
+	req, err := StructToMap(data)
+	if err != nil {
+		return nil, err
+	}
+	err = s.client.WithShop(sid, tok).PostWithContext(ctx, path, req, resp)
+	return resp, err
+}
@@ -0,0 +1,188 @@
+package goshopee
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_GetReturnList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/returns/get_return_list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_return_list_resp.json")))
+
+	req := GetReturnListRequest{
+		PageNo:   0,
+		PageSize: 20,
+		Status:   ReturnStatusProcessing,
+	}
+	res, err := client.Returns.GetReturnList(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Returns.GetReturnList error: %s", err)
+	}
+
+	t.Logf("Returns.GetReturnList: %#v", res)
+
+	var expected string = "200127161352386"
+	if res.Response.Return[0].ReturnSN != expected {
+		t.Errorf("Return[0].ReturnSN returned %+v, expected %+v", res.Response.Return[0].ReturnSN, expected)
+	}
+}
+
+func Test_GetReturnDetail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/returns/get_return_detail", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_return_detail_resp.json")))
+
+	res, err := client.Returns.GetReturnDetail(shopID, "200127161352386", accessToken)
+	if err != nil {
+		t.Errorf("Returns.GetReturnDetail error: %s", err)
+	}
+
+	t.Logf("Returns.GetReturnDetail: %#v", res)
+
+	var expected float64 = 25.9
+	if res.Response.Item[0].RefundAmount != expected {
+		t.Errorf("Item[0].RefundAmount returned %+v, expected %+v", res.Response.Item[0].RefundAmount, expected)
+	}
+	if res.Response.Negotiation.NegotiationStatus != ReturnNegotiationPendingRespond {
+		t.Errorf("Negotiation.NegotiationStatus returned %+v, expected %+v", res.Response.Negotiation.NegotiationStatus, ReturnNegotiationPendingRespond)
+	}
+}
+
+func Test_ConfirmReturn(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/returns/confirm", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("return_sn_resp.json")))
+
+	res, err := client.Returns.Confirm(shopID, "200127161352386", accessToken)
+	if err != nil {
+		t.Errorf("Returns.Confirm error: %s", err)
+	}
+
+	t.Logf("Returns.Confirm: %#v", res)
+
+	var expected string = "200127161352386"
+	if res.Response.ReturnSN != expected {
+		t.Errorf("ReturnSN returned %+v, expected %+v", res.Response.ReturnSN, expected)
+	}
+}
+
+func Test_DisputeReturn(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/returns/dispute", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("dispute_return_resp.json")))
+
+	var req DisputeReturnRequest
+	loadMockData("dispute_return_req.json", &req)
+
+	res, err := client.Returns.Dispute(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Returns.Dispute error: %s", err)
+	}
+
+	t.Logf("Returns.Dispute: %#v", res)
+
+	var expected string = "dispute submitted"
+	if res.Response.Msg != expected {
+		t.Errorf("Msg returned %+v, expected %+v", res.Response.Msg, expected)
+	}
+}
+
+func Test_OfferReturn(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/returns/offer", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("return_sn_resp.json")))
+
+	req := OfferReturnRequest{
+		ReturnSN:                     "200127161352386",
+		ProposedSolution:             ReturnSolutionRefund,
+		ProposedAdjustedRefundAmount: 10,
+	}
+	res, err := client.Returns.Offer(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Returns.Offer error: %s", err)
+	}
+
+	t.Logf("Returns.Offer: %#v", res)
+
+	var expected string = "200127161352386"
+	if res.Response.ReturnSN != expected {
+		t.Errorf("ReturnSN returned %+v, expected %+v", res.Response.ReturnSN, expected)
+	}
+}
+
+func Test_AcceptReturnOffer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/returns/accept_offer", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("return_sn_resp.json")))
+
+	res, err := client.Returns.AcceptOffer(shopID, "200127161352386", accessToken)
+	if err != nil {
+		t.Errorf("Returns.AcceptOffer error: %s", err)
+	}
+
+	t.Logf("Returns.AcceptOffer: %#v", res)
+
+	var expected string = "200127161352386"
+	if res.Response.ReturnSN != expected {
+		t.Errorf("ReturnSN returned %+v, expected %+v", res.Response.ReturnSN, expected)
+	}
+}
+
+func Test_GetAvailableSolutions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/returns/get_available_solutions", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_available_solutions_resp.json")))
+
+	res, err := client.Returns.GetAvailableSolutions(shopID, "200127161352386", accessToken)
+	if err != nil {
+		t.Errorf("Returns.GetAvailableSolutions error: %s", err)
+	}
+
+	t.Logf("Returns.GetAvailableSolutions: %#v", res)
+
+	if res.Response.Solution[1].Solution != ReturnSolutionRefund {
+		t.Errorf("Solution[1].Solution returned %+v, expected %+v", res.Response.Solution[1].Solution, ReturnSolutionRefund)
+	}
+}
+
+func Test_UploadReturnProof(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/returns/upload_proof", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("response.json")))
+
+	req := UploadReturnProofRequest{
+		ReturnSN:    "200127161352386",
+		Photo:       []UploadReturnProofPhoto{{Thumbnail: "https://cf.shopee.sg/file/a_tn", URL: "https://cf.shopee.sg/file/a"}},
+		Description: "packed in bubble wrap",
+	}
+	res, err := client.Returns.UploadProof(shopID, req, accessToken)
+	if err != nil {
+		t.Errorf("Returns.UploadProof error: %s", err)
+	}
+
+	t.Logf("Returns.UploadProof: %#v", res)
+
+	var expected string = "f634ea27eff8461b8f6f9ffa1d7ddab2"
+	if res.RequestID != expected {
+		t.Errorf("RequestID returned %+v, expected %+v", res.RequestID, expected)
+	}
+}
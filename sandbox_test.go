@@ -0,0 +1,131 @@
+package goshopee
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/passwind/go-shopee-v2/shopeetest"
+)
+
+func newSandbox(t *testing.T, opts ...Option) (*shopeetest.Server, *Client) {
+	srv := shopeetest.NewServer(12345678, "hush")
+	t.Cleanup(srv.Close)
+	srv.AddShop(&shopeetest.Shop{ShopID: shopID, Name: "sandbox", Region: "SG"})
+
+	c := NewClient(App{PartnerID: srv.PartnerID, PartnerKey: srv.PartnerKey, APIURL: srv.URL}, opts...)
+	return srv, c
+}
+
+func Test_SandboxStock(t *testing.T) {
+	srv, c := newSandbox(t)
+	tok := srv.IssueToken(shopID)
+	item := &shopeetest.Item{Name: "shirt", Models: []*shopeetest.Model{{SKU: "S", Stock: 1}, {SKU: "M", Stock: 2}}}
+	if err := srv.AddItem(shopID, item); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := c.Shop.GetShopInfo(shopID, tok)
+	if err != nil {
+		t.Fatalf("Shop.GetShopInfo error: %s", err)
+	}
+	if info.ShopName != "sandbox" || info.Region != "SG" {
+		t.Errorf("Shop.GetShopInfo returned %+v", info.ShopInfo)
+	}
+
+	m := item.Models[1].ModelID
+	res, err := c.Product.UpdateStock(shopID, UpdateStockRequest{
+		ItemID:    item.ItemID,
+		StockList: []UpdateStockRequestData{{ModelID: m, NormalStock: 7}, {ModelID: 1, NormalStock: 1}},
+	}, tok)
+	if err != nil {
+		t.Fatalf("Product.UpdateStock error: %s", err)
+	}
+	if len(res.Response.SuccessList) != 1 || len(res.Response.FailureList) != 1 {
+		t.Errorf("Product.UpdateStock returned %+v", res.Response)
+	}
+
+	models, err := c.Product.GetModelList(shopID, item.ItemID, tok)
+	if err != nil {
+		t.Fatalf("Product.GetModelList error: %s", err)
+	}
+	if got := models.Response.Model[1].StockInfo[0].NormalStock; got != 7 {
+		t.Errorf("model stock is %d, expected 7", got)
+	}
+}
+
+func Test_SandboxRetry(t *testing.T) {
+	policy := NewBackoffPolicy(3)
+	policy.BaseDelay = time.Millisecond
+	srv, c := newSandbox(t, WithRetryPolicy(policy))
+	tok := srv.IssueToken(shopID)
+	srv.AddFault(shopeetest.ServerErrorFault("/shop/get_profile", 2))
+
+	if _, err := c.Shop.GetProfile(shopID, tok); err != nil {
+		t.Fatalf("Shop.GetProfile error: %s", err)
+	}
+	if n := srv.Calls("/shop/get_profile"); n != 3 {
+		t.Errorf("%d calls, expected 3", n)
+	}
+}
+
+func Test_SandboxTokenRefresh(t *testing.T) {
+	store := NewMemoryTokenStore()
+	srv, c := newSandbox(t, WithTokenStore(store))
+
+	auth, err := c.Auth.GetAccessToken(shopID, 0, srv.AuthCode(shopID))
+	if err != nil {
+		t.Fatalf("Auth.GetAccessToken error: %s", err)
+	}
+	if err := store.SetToken(context.Background(), ShopTokenKey(shopID), auth.Token()); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.ExpireTokens(shopID)
+	if _, err := c.Shop.GetProfile(shopID, ""); err != nil {
+		t.Fatalf("Shop.GetProfile error: %s", err)
+	}
+	tok, _ := store.GetToken(context.Background(), ShopTokenKey(shopID))
+	if tok.AccessToken == auth.AccessToken || tok.RefreshToken == auth.RefreshToken {
+		t.Errorf("token not refreshed: %+v", tok)
+	}
+}
+
+func Test_SandboxUploadInvoiceDoc(t *testing.T) {
+	store := NewMemoryTokenStore()
+	srv, c := newSandbox(t, WithTokenStore(store))
+	if err := srv.AddOrder(shopID, &shopeetest.Order{OrderSN: "SN1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := c.Auth.GetAccessToken(shopID, 0, srv.AuthCode(shopID))
+	if err != nil {
+		t.Fatalf("Auth.GetAccessToken error: %s", err)
+	}
+	if err := store.SetToken(context.Background(), ShopTokenKey(shopID), auth.Token()); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := []byte("%PDF-1.4 invoice")
+	filename := filepath.Join(t.TempDir(), "invoice.pdf")
+	if err := os.WriteFile(filename, doc, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the stored token is expired: the upload refreshes it and sends the file again
+	srv.ExpireTokens(shopID)
+	req := UploadInvoiceDocRequest{OrderSN: "SN1", FileType: InvoiceFileTypePDF, Filename: filename}
+	if _, err := c.Order.UploadInvoiceDoc(shopID, req, ""); err != nil {
+		t.Fatalf("Order.UploadInvoiceDoc error: %s", err)
+	}
+	o, _ := srv.Order(shopID, "SN1")
+	if !bytes.Equal(o.InvoiceDoc, doc) {
+		t.Errorf("uploaded %q, expected %q", o.InvoiceDoc, doc)
+	}
+	if n := srv.Calls("/order/upload_invoice_doc"); n != 2 {
+		t.Errorf("%d calls, expected 2", n)
+	}
+}
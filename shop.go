@@ -1,8 +1,12 @@
 package goshopee
 
+import "context"
+
 type ShopService interface {
-	GetShopInfo (uint64, string) (*GetShopInfoResponse, error)
-	GetProfile (uint64, string) (*GetProfileResponse, error)
+	GetShopInfo(uint64, string) (*GetShopInfoResponse, error)
+	GetShopInfoWithContext(context.Context, uint64, string) (*GetShopInfoResponse, error)
+	GetProfile(uint64, string) (*GetProfileResponse, error)
+	GetProfileWithContext(context.Context, uint64, string) (*GetProfileResponse, error)
 }
 
 type ShopServiceOp struct {
@@ -10,32 +14,36 @@ type ShopServiceOp struct {
 }
 
 type ShopInfo struct {
-	ShopName string `json:"shop_name"`
-	Region string `json:"region"`
-	Status string `json:"status"`
+	ShopName     string         `json:"shop_name"`
+	Region       string         `json:"region"`
+	Status       string         `json:"status"`
 	SIPAffiShops []SIPAffiShops `json:"sip_affi_shops"`
-	IsCB bool `json:"is_cb"`
-	IsCNSC bool `json:"is_cnsc"`
+	IsCB         bool           `json:"is_cb"`
+	IsCNSC       bool           `json:"is_cnsc"`
 }
 
 type SIPAffiShops struct {
 	AffiShopID uint64 `json:"affi_shop_id"`
-	Region string `json:"region"`
+	Region     string `json:"region"`
 }
 
 type GetShopInfoResponse struct {
 	ShopInfo
 
-	RequestID string `json:"request_id"`
-	AuthTime int64 `json:"auth_time"`
-	ExpireTime int64 `json:"expire_time"`
+	RequestID  string `json:"request_id"`
+	AuthTime   int64  `json:"auth_time"`
+	ExpireTime int64  `json:"expire_time"`
+}
+
+func (s *ShopServiceOp) GetShopInfo(sid uint64, tok string) (*GetShopInfoResponse, error) {
+	return s.GetShopInfoWithContext(context.Background(), sid, tok)
 }
 
-func (s *ShopServiceOp)GetShopInfo (sid uint64, tok string) (*GetShopInfoResponse, error){
+func (s *ShopServiceOp) GetShopInfoWithContext(ctx context.Context, sid uint64, tok string) (*GetShopInfoResponse, error) {
 	path := "shop/get_shop_info"
 
 	resp := new(GetShopInfoResponse)
-	err := s.client.WithShop(sid,tok).Get(path, resp, nil)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, nil)
 	return resp, err
 }
 
@@ -46,15 +54,19 @@ type GetProfileResponse struct {
 }
 
 type ShopProfile struct {
-	ShopLogo string `json:"shop_logo"`
+	ShopLogo    string `json:"shop_logo"`
 	Description string `json:"description"`
-	ShopName string `json:"shop_name"`
+	ShopName    string `json:"shop_name"`
 }
 
-func (s *ShopServiceOp)GetProfile (sid uint64, tok string) (*GetProfileResponse, error){
+func (s *ShopServiceOp) GetProfile(sid uint64, tok string) (*GetProfileResponse, error) {
+	return s.GetProfileWithContext(context.Background(), sid, tok)
+}
+
+func (s *ShopServiceOp) GetProfileWithContext(ctx context.Context, sid uint64, tok string) (*GetProfileResponse, error) {
 	path := "shop/get_profile"
 
 	resp := new(GetProfileResponse)
-	err := s.client.WithShop(sid,tok).Get(path, resp, nil)
+	err := s.client.WithShop(sid, tok).GetWithContext(ctx, path, resp, nil)
 	return resp, err
-}
\ No newline at end of file
+}
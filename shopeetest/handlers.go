@@ -0,0 +1,622 @@
+package shopeetest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// topLevel is a handler result whose fields go next to request_id instead
+// of under response, like the auth and shop info apis answer
+type topLevel map[string]interface{}
+
+type handler func(s *Server, c *call) (interface{}, *apiError)
+
+// handlers by api path. They run with s.mu held.
+var handlers map[string]handler
+
+func init() {
+	handlers = map[string]handler{
+		"/auth/token/get":             getToken,
+		"/auth/access_token/get":      refreshToken,
+		"/shop/get_shop_info":         getShopInfo,
+		"/shop/get_profile":           getProfile,
+		"/product/get_item_list":      getItemList,
+		"/product/get_item_base_info": getItemBaseInfo,
+		"/product/get_model_list":     getModelList,
+		"/product/update_stock":       updateStock,
+		"/product/update_price":       updatePrice,
+		"/order/get_order_list":       getOrderList,
+		"/order/get_order_detail":     getOrderDetail,
+		"/order/upload_invoice_doc":   uploadInvoiceDoc,
+		"/discount/get_discount_list": getDiscountList,
+		"/discount/add_discount":      addDiscount,
+	}
+}
+
+const (
+	orderListMaxTimeRange = 15 * 24 * time.Hour
+	orderDetailMaxSNList  = 50
+	itemBaseInfoMaxIDs    = 50
+	maxPageSize           = 100
+)
+
+func (s *Server) shop(c *call) (*Shop, *apiError) {
+	shop, ok := s.shops[c.shopID]
+	if !ok {
+		return nil, errNotFound("Shop %d not found.", c.shopID)
+	}
+	return shop, nil
+}
+
+func getToken(s *Server, c *call) (interface{}, *apiError) {
+	var req struct {
+		Code   string `json:"code"`
+		ShopID uint64 `json:"shop_id"`
+	}
+	if err := c.decode(&req); err != nil {
+		return nil, err
+	}
+	t, ok := s.codes[req.Code]
+	if !ok || t.shopID != req.ShopID {
+		return nil, &apiError{http.StatusForbidden, "error_auth", "Invalid code."}
+	}
+	delete(s.codes, req.Code)
+
+	access, refresh := s.issue(t)
+	return topLevel{
+		"access_token":     access,
+		"refresh_token":    refresh,
+		"expire_in":        int(TokenLifetime / time.Second),
+		"shop_id_list":     []uint64{t.shopID},
+		"merchant_id_list": []uint64{},
+	}, nil
+}
+
+func refreshToken(s *Server, c *call) (interface{}, *apiError) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+		ShopID       uint64 `json:"shop_id"`
+		MerchantID   uint64 `json:"merchant_id"`
+	}
+	if err := c.decode(&req); err != nil {
+		return nil, err
+	}
+	t, ok := s.refreshes[req.RefreshToken]
+	if !ok || t.shopID != req.ShopID || t.merchantID != req.MerchantID {
+		return nil, &apiError{http.StatusForbidden, "error_auth", "Invalid refresh_token."}
+	}
+	// refresh tokens are single use
+	delete(s.refreshes, req.RefreshToken)
+
+	access, refresh := s.issue(t)
+	return topLevel{
+		"access_token":  access,
+		"refresh_token": refresh,
+		"expire_in":     int(TokenLifetime / time.Second),
+		"partner_id":    s.PartnerID,
+		"shop_id":       t.shopID,
+		"merchant_id":   t.merchantID,
+	}, nil
+}
+
+func getShopInfo(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	return topLevel{
+		"shop_name":   shop.Name,
+		"region":      shop.Region,
+		"status":      shop.Status,
+		"auth_time":   now.Unix(),
+		"expire_time": now.AddDate(1, 0, 0).Unix(),
+	}, nil
+}
+
+func getProfile(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"shop_name":   shop.Name,
+		"shop_logo":   "",
+		"description": "",
+	}, nil
+}
+
+func getItemList(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := intParam(c, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := intParam(c, "page_size", 10)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		return nil, errParam("page_size should be between 1 and %d.", maxPageSize)
+	}
+	statuses := listParam(c, "item_status")
+	if len(statuses) == 0 {
+		return nil, errParam("item_status is required.")
+	}
+	wanted := make(map[string]bool)
+	for _, st := range statuses {
+		wanted[st] = true
+	}
+
+	var items []*Item
+	for _, it := range shop.items {
+		if wanted[it.Status] {
+			items = append(items, it)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ItemID < items[j].ItemID })
+
+	list := []map[string]interface{}{}
+	for i := offset; i < len(items) && i < offset+pageSize; i++ {
+		list = append(list, map[string]interface{}{
+			"item_id":     items[i].ItemID,
+			"item_status": items[i].Status,
+			"update_time": items[i].UpdateTime,
+		})
+	}
+	next := offset + len(list)
+	return map[string]interface{}{
+		"item":          list,
+		"total_count":   len(items),
+		"has_next_page": next < len(items),
+		"next_offset":   next,
+	}, nil
+}
+
+func getItemBaseInfo(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := idsParam(c, "item_id_list")
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 || len(ids) > itemBaseInfoMaxIDs {
+		return nil, errParam("item_id_list should have 1 to %d items.", itemBaseInfoMaxIDs)
+	}
+
+	list := []map[string]interface{}{}
+	for _, id := range ids {
+		it, ok := shop.items[id]
+		if !ok {
+			continue
+		}
+		info := map[string]interface{}{
+			"item_id":     it.ItemID,
+			"item_name":   it.Name,
+			"item_sku":    it.SKU,
+			"item_status": it.Status,
+			"category_id": it.CategoryID,
+			"update_time": it.UpdateTime,
+			"has_model":   len(it.Models) > 0,
+		}
+		if len(it.Models) == 0 {
+			info["price_info"] = []interface{}{priceInfo(it.Currency, it.Price)}
+			info["stock_info"] = []interface{}{stockInfo(it.Stock)}
+		}
+		list = append(list, info)
+	}
+	return map[string]interface{}{"item_list": list}, nil
+}
+
+func getModelList(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	id, perr := strconv.ParseUint(c.query.Get("item_id"), 10, 64)
+	if perr != nil {
+		return nil, errParam("Invalid item_id.")
+	}
+	it, ok := shop.items[id]
+	if !ok {
+		return nil, &apiError{http.StatusNotFound, "error_item_not_found", "Item not found."}
+	}
+
+	models := []map[string]interface{}{}
+	for _, m := range it.Models {
+		models = append(models, map[string]interface{}{
+			"model_id":   m.ModelID,
+			"model_sku":  m.SKU,
+			"tier_index": m.TierIndex,
+			"price_info": []interface{}{priceInfo(it.Currency, m.Price)},
+			"stock_info": []interface{}{stockInfo(m.Stock)},
+		})
+	}
+	return map[string]interface{}{
+		"tier_variation": []interface{}{},
+		"model":          models,
+	}, nil
+}
+
+func updateStock(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		ItemID    uint64 `json:"item_id"`
+		StockList []struct {
+			ModelID     uint64 `json:"model_id"`
+			NormalStock int    `json:"normal_stock"`
+		} `json:"stock_list"`
+	}
+	if err := c.decode(&req); err != nil {
+		return nil, err
+	}
+	it, ok := shop.items[req.ItemID]
+	if !ok {
+		return nil, &apiError{http.StatusNotFound, "error_item_not_found", "Item not found."}
+	}
+
+	success := []map[string]interface{}{}
+	failure := []map[string]interface{}{}
+	for _, st := range req.StockList {
+		stock := &it.Stock
+		if len(it.Models) > 0 || st.ModelID != 0 {
+			m := it.model(st.ModelID)
+			if m == nil {
+				failure = append(failure, map[string]interface{}{"model_id": st.ModelID, "failed_reason": "Model not found."})
+				continue
+			}
+			stock = &m.Stock
+		}
+		if st.NormalStock < 0 {
+			failure = append(failure, map[string]interface{}{"model_id": st.ModelID, "failed_reason": "Stock should not be negative."})
+			continue
+		}
+		*stock = st.NormalStock
+		success = append(success, map[string]interface{}{"model_id": st.ModelID, "normal_stock": st.NormalStock})
+	}
+	if len(success) > 0 {
+		it.UpdateTime = s.Now().Unix()
+	}
+	return map[string]interface{}{"success_list": success, "failure_list": failure}, nil
+}
+
+func updatePrice(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		ItemID    uint64 `json:"item_id"`
+		PriceList []struct {
+			ModelID       uint64  `json:"model_id"`
+			OriginalPrice float64 `json:"original_price"`
+		} `json:"price_list"`
+	}
+	if err := c.decode(&req); err != nil {
+		return nil, err
+	}
+	it, ok := shop.items[req.ItemID]
+	if !ok {
+		return nil, &apiError{http.StatusNotFound, "error_item_not_found", "Item not found."}
+	}
+
+	success := []map[string]interface{}{}
+	failure := []map[string]interface{}{}
+	for _, p := range req.PriceList {
+		price := &it.Price
+		if len(it.Models) > 0 || p.ModelID != 0 {
+			m := it.model(p.ModelID)
+			if m == nil {
+				failure = append(failure, map[string]interface{}{"model_id": p.ModelID, "failed_reason": "Model not found."})
+				continue
+			}
+			price = &m.Price
+		}
+		if p.OriginalPrice <= 0 {
+			failure = append(failure, map[string]interface{}{"model_id": p.ModelID, "failed_reason": "Price should be positive."})
+			continue
+		}
+		*price = p.OriginalPrice
+		success = append(success, map[string]interface{}{"model_id": p.ModelID, "original_price": p.OriginalPrice})
+	}
+	if len(success) > 0 {
+		it.UpdateTime = s.Now().Unix()
+	}
+	return map[string]interface{}{"success_list": success, "failure_list": failure}, nil
+}
+
+func getOrderList(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	field := c.query.Get("time_range_field")
+	if field != "create_time" && field != "update_time" {
+		return nil, errParam("time_range_field should be create_time or update_time.")
+	}
+	from, perr := strconv.ParseInt(c.query.Get("time_from"), 10, 64)
+	if perr != nil {
+		return nil, errParam("Invalid time_from.")
+	}
+	to, perr := strconv.ParseInt(c.query.Get("time_to"), 10, 64)
+	if perr != nil {
+		return nil, errParam("Invalid time_to.")
+	}
+	if to < from || time.Duration(to-from)*time.Second > orderListMaxTimeRange {
+		return nil, errParam("The time range should be no longer than 15 days.")
+	}
+	pageSize, err := intParam(c, "page_size", 0)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		return nil, errParam("page_size should be between 1 and %d.", maxPageSize)
+	}
+	offset := 0
+	if cur := c.query.Get("cursor"); cur != "" {
+		if offset, perr = strconv.Atoi(cur); perr != nil || offset < 0 {
+			return nil, errParam("Invalid cursor.")
+		}
+	}
+	status := c.query.Get("order_status")
+
+	var orders []*Order
+	for _, o := range shop.orders {
+		t := o.CreateTime
+		if field == "update_time" {
+			t = o.UpdateTime
+		}
+		if t < from || t > to || (status != "" && o.Status != status) {
+			continue
+		}
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].OrderSN < orders[j].OrderSN })
+
+	list := []map[string]interface{}{}
+	for i := offset; i < len(orders) && i < offset+pageSize; i++ {
+		list = append(list, map[string]interface{}{
+			"order_sn":     orders[i].OrderSN,
+			"order_status": orders[i].Status,
+		})
+	}
+	next := offset + len(list)
+	more := next < len(orders)
+	cursor := ""
+	if more {
+		cursor = strconv.Itoa(next)
+	}
+	return map[string]interface{}{
+		"order_list":  list,
+		"more":        more,
+		"next_cursor": cursor,
+	}, nil
+}
+
+func getOrderDetail(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	sns := listParam(c, "order_sn_list")
+	if len(sns) == 0 || len(sns) > orderDetailMaxSNList {
+		return nil, errParam("order_sn_list should have 1 to %d orders.", orderDetailMaxSNList)
+	}
+
+	list := []map[string]interface{}{}
+	for _, sn := range sns {
+		o, ok := shop.orders[sn]
+		if !ok {
+			continue
+		}
+		items := []map[string]interface{}{}
+		for _, oi := range o.Items {
+			items = append(items, map[string]interface{}{
+				"item_id":                  oi.ItemID,
+				"model_id":                 oi.ModelID,
+				"model_quantity_purchased": oi.Quantity,
+				"model_original_price":     oi.Price,
+				"model_discounted_price":   oi.Price,
+			})
+		}
+		list = append(list, map[string]interface{}{
+			"order_sn":     o.OrderSN,
+			"region":       shop.Region,
+			"currency":     o.Currency,
+			"total_amount": o.TotalAmount,
+			"order_status": o.Status,
+			"create_time":  o.CreateTime,
+			"update_time":  o.UpdateTime,
+			"item_list":    items,
+		})
+	}
+	return map[string]interface{}{"order_list": list}, nil
+}
+
+func uploadInvoiceDoc(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.form == nil {
+		return nil, errParam("Expected a multipart form.")
+	}
+	sn := c.form.Value["order_sn"]
+	if len(sn) == 0 {
+		return nil, errParam("Missing order_sn.")
+	}
+	o, ok := shop.orders[sn[0]]
+	if !ok {
+		return nil, errNotFound("Order %s not found.", sn[0])
+	}
+	files := c.form.File["file"]
+	if len(files) == 0 {
+		return nil, errParam("Missing file.")
+	}
+	f, ferr := files[0].Open()
+	if ferr != nil {
+		return nil, errParam("Invalid file: %s", ferr)
+	}
+	defer f.Close()
+	doc, ferr := ioutil.ReadAll(f)
+	if ferr != nil {
+		return nil, errParam("Invalid file: %s", ferr)
+	}
+	o.InvoiceDoc = doc
+	return nil, nil
+}
+
+func getDiscountList(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	status := c.query.Get("discount_status")
+	switch status {
+	case "upcoming", "ongoing", "expired", "all":
+	default:
+		return nil, errParam("discount_status should be upcoming, ongoing, expired or all.")
+	}
+	pageNo, err := intParam(c, "page_no", 1)
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := intParam(c, "page_size", 0)
+	if err != nil {
+		return nil, err
+	}
+	if pageNo < 1 || pageSize < 1 || pageSize > maxPageSize {
+		return nil, errParam("Invalid page_no or page_size.")
+	}
+
+	now := s.Now().Unix()
+	var discounts []*Discount
+	for _, d := range shop.discounts {
+		if status == "all" || discountStatus(d, now) == status {
+			discounts = append(discounts, d)
+		}
+	}
+	sort.Slice(discounts, func(i, j int) bool { return discounts[i].DiscountID < discounts[j].DiscountID })
+
+	list := []map[string]interface{}{}
+	offset := (pageNo - 1) * pageSize
+	for i := offset; i < len(discounts) && i < offset+pageSize; i++ {
+		d := discounts[i]
+		list = append(list, map[string]interface{}{
+			"discount_id":   d.DiscountID,
+			"discount_name": d.Name,
+			"start_time":    d.StartTime,
+			"end_time":      d.EndTime,
+			"status":        discountStatus(d, now),
+		})
+	}
+	return map[string]interface{}{
+		"discount_list": list,
+		"more":          offset+len(list) < len(discounts),
+	}, nil
+}
+
+func addDiscount(s *Server, c *call) (interface{}, *apiError) {
+	shop, err := s.shop(c)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		DiscountName string `json:"discount_name"`
+		StartTime    int64  `json:"start_time"`
+		EndTime      int64  `json:"end_time"`
+	}
+	if err := c.decode(&req); err != nil {
+		return nil, err
+	}
+	if req.DiscountName == "" {
+		return nil, errParam("discount_name is required.")
+	}
+	if req.StartTime <= s.Now().Unix() {
+		return nil, errParam("start_time should be later than now.")
+	}
+	if req.EndTime < req.StartTime+int64(time.Hour/time.Second) {
+		return nil, errParam("end_time should be at least one hour later than start_time.")
+	}
+
+	d := &Discount{DiscountID: s.newID(), Name: req.DiscountName, StartTime: req.StartTime, EndTime: req.EndTime}
+	shop.discounts[d.DiscountID] = d
+	return map[string]interface{}{"discount_id": d.DiscountID}, nil
+}
+
+func discountStatus(d *Discount, now int64) string {
+	switch {
+	case now < d.StartTime:
+		return "upcoming"
+	case now < d.EndTime:
+		return "ongoing"
+	default:
+		return "expired"
+	}
+}
+
+func priceInfo(currency string, price float64) map[string]interface{} {
+	return map[string]interface{}{
+		"currency":       currency,
+		"original_price": price,
+		"current_price":  price,
+	}
+}
+
+func stockInfo(stock int) map[string]interface{} {
+	return map[string]interface{}{
+		"stock_type":    2,
+		"normal_stock":  stock,
+		"current_stock": stock,
+	}
+}
+
+// listParam reads a list sent either comma separated or as repeated
+// parameters
+func listParam(c *call, key string) []string {
+	var res []string
+	for _, v := range c.query[key] {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+func idsParam(c *call, key string) ([]uint64, *apiError) {
+	var ids []uint64
+	for _, v := range listParam(c, key) {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, errParam("Invalid %s.", key)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func intParam(c *call, key string, def int) (int, *apiError) {
+	v := c.query.Get(key)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, errParam("Invalid %s.", key)
+	}
+	return n, nil
+}
@@ -0,0 +1,382 @@
+// Package shopeetest provides an in-process fake of the Shopee Open API v2
+// for tests. The Server checks partner_id, timestamp and sign the way Shopee
+// does, keeps shops, items, orders and discounts in memory and can be
+// scripted to fail with rate limits, server errors or expired tokens.
+//
+//	srv := shopeetest.NewServer(partnerID, partnerKey)
+//	defer srv.Close()
+//	srv.AddShop(&shopeetest.Shop{ShopID: 1, Name: "test shop"})
+//	tok := srv.IssueToken(1)
+//
+//	client := goshopee.NewClient(goshopee.App{PartnerID: partnerID, PartnerKey: partnerKey, APIURL: srv.URL})
+//	client.Shop.GetShopInfo(1, tok)
+package shopeetest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	apiPrefix = "/api/v2"
+
+	// TimestampWindow is how far the timestamp of a request may be from the
+	// server clock
+	TimestampWindow = 5 * time.Minute
+
+	// TokenLifetime is how long an access token stays valid
+	TokenLifetime = 4 * time.Hour
+
+	maxUploadSize = 10 << 20
+)
+
+// publicPaths are signed without access token, shop or merchant
+var publicPaths = map[string]bool{
+	"/auth/token/get":              true,
+	"/auth/access_token/get":       true,
+	"/public/get_shops_by_partner": true,
+	"/push/set_app_push_config":    true,
+	"/push/get_app_push_config":    true,
+}
+
+// Fault makes matching requests fail. A zero Path or ShopID matches every
+// path or shop. The fault is removed after failing Times requests, or never
+// if Times is 0.
+type Fault struct {
+	Path       string // api path without the /api/v2 prefix, e.g. /product/update_stock
+	ShopID     uint64
+	Times      int
+	Status     int
+	Error      string
+	Message    string
+	RetryAfter int // seconds, sent as Retry-After header when not 0
+}
+
+// RateLimitFault fails times requests to path with error_rate_limit
+func RateLimitFault(path string, times int) Fault {
+	return Fault{Path: path, Times: times, Status: http.StatusTooManyRequests, Error: "error_rate_limit", Message: "Too many requests."}
+}
+
+// ServerErrorFault fails times requests to path with error_server
+func ServerErrorFault(path string, times int) Fault {
+	return Fault{Path: path, Times: times, Status: http.StatusInternalServerError, Error: "error_server", Message: "System error."}
+}
+
+type token struct {
+	shopID     uint64
+	merchantID uint64
+	expireAt   time.Time
+}
+
+// Server is a fake Shopee api server. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	PartnerID  int
+	PartnerKey string
+
+	// Now is the server clock, used for timestamps and token expiry
+	Now func() time.Time
+
+	mu        sync.Mutex
+	shops     map[uint64]*Shop
+	tokens    map[string]token // by access token
+	refreshes map[string]token // by refresh token
+	codes     map[string]token // by auth code
+	faults    []*Fault
+	calls     map[string]int
+	nextID    uint64
+}
+
+// NewServer starts a server for the partner partnerID signing with
+// partnerKey. Close it when done.
+func NewServer(partnerID int, partnerKey string) *Server {
+	s := &Server{
+		PartnerID:  partnerID,
+		PartnerKey: partnerKey,
+		Now:        time.Now,
+		shops:      make(map[uint64]*Shop),
+		tokens:     make(map[string]token),
+		refreshes:  make(map[string]token),
+		codes:      make(map[string]token),
+		calls:      make(map[string]int),
+		nextID:     100000,
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+func (s *Server) newID() uint64 {
+	s.nextID++
+	return s.nextID
+}
+
+// IssueToken returns a valid access token for shop sid, as if the seller
+// had authorized the app
+func (s *Server) IssueToken(sid uint64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	access, _ := s.issue(token{shopID: sid})
+	return access
+}
+
+// IssueMerchantToken returns a valid access token for merchant mid
+func (s *Server) IssueMerchantToken(mid uint64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	access, _ := s.issue(token{merchantID: mid})
+	return access
+}
+
+// AuthCode returns a code to exchange for tokens of shop sid with
+// /auth/token/get, as Shopee sends it to the redirect url
+func (s *Server) AuthCode(sid uint64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	code := fmt.Sprintf("code-%d", s.newID())
+	s.codes[code] = token{shopID: sid}
+	return code
+}
+
+func (s *Server) issue(t token) (access, refresh string) {
+	id := s.newID()
+	access = fmt.Sprintf("access-%d", id)
+	refresh = fmt.Sprintf("refresh-%d", id)
+	t.expireAt = s.Now().Add(TokenLifetime)
+	s.tokens[access] = t
+	s.refreshes[refresh] = t
+	return access, refresh
+}
+
+// ExpireTokens makes every access token of shop sid invalid, the refresh
+// tokens still work
+func (s *Server) ExpireTokens(sid uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, t := range s.tokens {
+		if t.shopID == sid {
+			t.expireAt = s.Now()
+			s.tokens[k] = t
+		}
+	}
+}
+
+// AddFault scripts a failure, see Fault
+func (s *Server) AddFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every scripted failure
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Calls returns how many requests reached path, failed ones included
+func (s *Server) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+// apiError is an error response of the api
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func errParam(format string, v ...interface{}) *apiError {
+	return &apiError{http.StatusBadRequest, "error_param", fmt.Sprintf(format, v...)}
+}
+
+func errNotFound(format string, v ...interface{}) *apiError {
+	return &apiError{http.StatusNotFound, "error_not_found", fmt.Sprintf(format, v...)}
+}
+
+// call is the context of an authenticated request
+type call struct {
+	path       string
+	shopID     uint64
+	merchantID uint64
+	query      url.Values
+	body       []byte
+	form       *multipart.Form // of file uploads
+}
+
+// decode reads the json body of the call into v
+func (c *call) decode(v interface{}) *apiError {
+	if len(c.body) == 0 {
+		return errParam("Missing json body.")
+	}
+	if err := json.Unmarshal(c.body, v); err != nil {
+		return errParam("Invalid json body: %s", err)
+	}
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, apiPrefix)
+	s.mu.Lock()
+	s.calls[path]++
+	requestID := fmt.Sprintf("%032x", s.newID())
+	s.mu.Unlock()
+
+	c, aerr := s.authenticate(r, path)
+	if aerr == nil {
+		aerr = s.fault(w, c)
+	}
+	if aerr != nil {
+		writeError(w, requestID, aerr)
+		return
+	}
+
+	h, ok := handlers[path]
+	if !ok {
+		writeError(w, requestID, &apiError{http.StatusNotFound, "error_not_found", "Wrong api path."})
+		return
+	}
+
+	s.mu.Lock()
+	resp, aerr := h(s, c)
+	s.mu.Unlock()
+	if aerr != nil {
+		writeError(w, requestID, aerr)
+		return
+	}
+
+	body := map[string]interface{}{"request_id": requestID, "error": "", "message": ""}
+	if m, ok := resp.(topLevel); ok {
+		// top level fields, like the auth and shop info apis have
+		for k, v := range m {
+			body[k] = v
+		}
+	} else if resp != nil {
+		body["response"] = resp
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// authenticate checks the common parameters and the signature of r
+func (s *Server) authenticate(r *http.Request, path string) (*call, *apiError) {
+	q := r.URL.Query()
+	c := &call{path: path, query: q}
+
+	if r.Method == http.MethodPost {
+		if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "multipart/form-data" {
+			if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+				return nil, errParam("Invalid form: %s", err)
+			}
+			c.form = r.MultipartForm
+		} else {
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return nil, errParam("Invalid body: %s", err)
+			}
+			if len(b) > 0 && !json.Valid(b) {
+				return nil, errParam("Invalid json body.")
+			}
+			c.body = b
+		}
+	}
+
+	pid, err := strconv.Atoi(q.Get("partner_id"))
+	if err != nil || pid != s.PartnerID {
+		return nil, &apiError{http.StatusForbidden, "error_param", "Wrong partner_id."}
+	}
+
+	ts, err := strconv.ParseInt(q.Get("timestamp"), 10, 64)
+	if err != nil {
+		return nil, errParam("Invalid timestamp.")
+	}
+	if d := s.Now().Sub(time.Unix(ts, 0)); d > TimestampWindow || d < -TimestampWindow {
+		return nil, &apiError{http.StatusForbidden, "invalid_timestamp", "Timestamp is expired."}
+	}
+
+	base := fmt.Sprintf("%d%s%d", pid, r.URL.Path, ts)
+	if !publicPaths[path] {
+		accessToken := q.Get("access_token")
+		switch {
+		case q.Get("shop_id") != "":
+			c.shopID, _ = strconv.ParseUint(q.Get("shop_id"), 10, 64)
+			base += accessToken + q.Get("shop_id")
+		case q.Get("merchant_id") != "":
+			c.merchantID, _ = strconv.ParseUint(q.Get("merchant_id"), 10, 64)
+			base += accessToken + q.Get("merchant_id")
+		default:
+			return nil, errParam("Missing shop_id or merchant_id.")
+		}
+
+		if !hmac.Equal([]byte(sign(s.PartnerKey, base)), []byte(q.Get("sign"))) {
+			return nil, &apiError{http.StatusForbidden, "error_sign", "Wrong sign."}
+		}
+
+		s.mu.Lock()
+		t, ok := s.tokens[accessToken]
+		s.mu.Unlock()
+		if !ok || !s.Now().Before(t.expireAt) || t.shopID != c.shopID || t.merchantID != c.merchantID {
+			return nil, &apiError{http.StatusForbidden, "invalid_access_token", "Invalid access_token."}
+		}
+		return c, nil
+	}
+
+	if !hmac.Equal([]byte(sign(s.PartnerKey, base)), []byte(q.Get("sign"))) {
+		return nil, &apiError{http.StatusForbidden, "error_sign", "Wrong sign."}
+	}
+	return c, nil
+}
+
+// fault applies the first scripted fault matching c
+func (s *Server) fault(w http.ResponseWriter, c *call) *apiError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if (f.Path != "" && f.Path != c.path) || (f.ShopID != 0 && f.ShopID != c.shopID) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(f.RetryAfter))
+		}
+		return &apiError{f.Status, f.Error, f.Message}
+	}
+	return nil
+}
+
+func sign(key, base string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(base))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeError(w http.ResponseWriter, requestID string, e *apiError) {
+	writeJSON(w, e.status, map[string]interface{}{"request_id": requestID, "error": e.code, "message": e.message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
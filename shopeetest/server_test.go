@@ -0,0 +1,133 @@
+package shopeetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	partnerID  = 1000
+	partnerKey = "key"
+	shopID     = 2000
+)
+
+func newTestServer(t *testing.T) *Server {
+	s := NewServer(partnerID, partnerKey)
+	t.Cleanup(s.Close)
+	s.AddShop(&Shop{ShopID: shopID, Name: "shop", Region: "SG"})
+	return s
+}
+
+// shopURL signs path for shop sid the way the api expects
+func shopURL(s *Server, path, tok string, sid uint64, ts int64, extra url.Values) string {
+	q := url.Values{}
+	for k, v := range extra {
+		q[k] = v
+	}
+	full := apiPrefix + path
+	q.Set("partner_id", fmt.Sprint(partnerID))
+	q.Set("timestamp", fmt.Sprint(ts))
+	q.Set("shop_id", fmt.Sprint(sid))
+	q.Set("access_token", tok)
+	q.Set("sign", sign(partnerKey, fmt.Sprintf("%d%s%d%s%d", partnerID, full, ts, tok, sid)))
+	return s.URL + full + "?" + q.Encode()
+}
+
+func getJSON(t *testing.T, u string) (int, map[string]interface{}) {
+	t.Helper()
+	resp, err := http.Get(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestServerAuthentication(t *testing.T) {
+	s := newTestServer(t)
+	tok := s.IssueToken(shopID)
+	now := time.Now().Unix()
+
+	cases := []struct {
+		name   string
+		url    string
+		status int
+		error  string
+	}{
+		{"valid", shopURL(s, "/shop/get_profile", tok, shopID, now, nil), http.StatusOK, ""},
+		{"bad sign", strings.Replace(shopURL(s, "/shop/get_profile", tok, shopID, now, nil), "sign=", "sign=0", 1), http.StatusForbidden, "error_sign"},
+		{"stale timestamp", shopURL(s, "/shop/get_profile", tok, shopID, now-600, nil), http.StatusForbidden, "invalid_timestamp"},
+		{"unknown token", shopURL(s, "/shop/get_profile", "nope", shopID, now, nil), http.StatusForbidden, "invalid_access_token"},
+		{"other shop", shopURL(s, "/shop/get_profile", tok, shopID+1, now, nil), http.StatusForbidden, "invalid_access_token"},
+		{"unknown path", shopURL(s, "/shop/nope", tok, shopID, now, nil), http.StatusNotFound, "error_not_found"},
+	}
+	for _, c := range cases {
+		status, body := getJSON(t, c.url)
+		if status != c.status || body["error"] != c.error {
+			t.Errorf("%s: got %d %v, expected %d %q", c.name, status, body["error"], c.status, c.error)
+		}
+		if body["request_id"] == "" {
+			t.Errorf("%s: no request_id", c.name)
+		}
+	}
+
+	s.ExpireTokens(shopID)
+	if status, body := getJSON(t, shopURL(s, "/shop/get_profile", tok, shopID, now, nil)); status != http.StatusForbidden || body["error"] != "invalid_access_token" {
+		t.Errorf("expired token: got %d %v", status, body["error"])
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	s := newTestServer(t)
+	tok := s.IssueToken(shopID)
+	f := RateLimitFault("/shop/get_profile", 2)
+	f.RetryAfter = 3
+	s.AddFault(f)
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(shopURL(s, "/shop/get_profile", tok, shopID, time.Now().Unix(), nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "3" {
+			t.Errorf("call %d: got %d Retry-After %q", i, resp.StatusCode, resp.Header.Get("Retry-After"))
+		}
+	}
+	if status, body := getJSON(t, shopURL(s, "/shop/get_profile", tok, shopID, time.Now().Unix(), nil)); status != http.StatusOK {
+		t.Errorf("fault not exhausted: got %d %v", status, body)
+	}
+	if n := s.Calls("/shop/get_profile"); n != 3 {
+		t.Errorf("Calls = %d, expected 3", n)
+	}
+}
+
+func TestServerItemList(t *testing.T) {
+	s := newTestServer(t)
+	tok := s.IssueToken(shopID)
+	for i := 0; i < 3; i++ {
+		if err := s.AddItem(shopID, &Item{Name: fmt.Sprint("item ", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.AddItem(shopID, &Item{Name: "gone", Status: "DELETED"})
+
+	q := url.Values{"offset": {"2"}, "page_size": {"2"}, "item_status": {"NORMAL", "BANNED"}}
+	status, body := getJSON(t, shopURL(s, "/product/get_item_list", tok, shopID, time.Now().Unix(), q))
+	if status != http.StatusOK {
+		t.Fatalf("got %d %v", status, body)
+	}
+	resp := body["response"].(map[string]interface{})
+	if resp["total_count"] != 3.0 || resp["has_next_page"] != false || len(resp["item"].([]interface{})) != 1 {
+		t.Errorf("unexpected page %v", resp)
+	}
+}
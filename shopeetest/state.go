@@ -0,0 +1,194 @@
+package shopeetest
+
+import "fmt"
+
+// Shop is a shop of the server with everything it sells and sold
+type Shop struct {
+	ShopID uint64
+	Name   string
+	Region string
+	Status string // NORMAL when empty
+
+	items     map[uint64]*Item
+	orders    map[string]*Order
+	discounts map[uint64]*Discount
+}
+
+// Item is a product of a shop. Items without models have their price and
+// stock on the item, the others on each model.
+type Item struct {
+	ItemID     uint64
+	Name       string
+	SKU        string
+	CategoryID uint64
+	Status     string // NORMAL when empty
+	Currency   string
+	Price      float64
+	Stock      int
+	Models     []*Model
+	UpdateTime int64
+}
+
+// Model is a variation of an item
+type Model struct {
+	ModelID   uint64
+	SKU       string
+	TierIndex []int
+	Price     float64
+	Stock     int
+}
+
+// Order is an order placed in a shop
+type Order struct {
+	OrderSN     string
+	Status      string // READY_TO_SHIP when empty
+	Currency    string
+	TotalAmount float64
+	CreateTime  int64
+	UpdateTime  int64
+	Items       []OrderItem
+	InvoiceDoc  []byte // uploaded with upload_invoice_doc
+}
+
+// OrderItem is a line of an order
+type OrderItem struct {
+	ItemID   uint64
+	ModelID  uint64
+	Quantity int
+	Price    float64
+}
+
+// Discount is a discount campaign of a shop
+type Discount struct {
+	DiscountID uint64
+	Name       string
+	StartTime  int64
+	EndTime    int64
+}
+
+// AddShop adds shop to the server, replacing any shop with the same id
+func (s *Server) AddShop(shop *Shop) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if shop.Status == "" {
+		shop.Status = "NORMAL"
+	}
+	shop.items = make(map[uint64]*Item)
+	shop.orders = make(map[string]*Order)
+	shop.discounts = make(map[uint64]*Discount)
+	s.shops[shop.ShopID] = shop
+}
+
+// AddItem adds item to shop sid. Zero item and model ids are assigned.
+func (s *Server) AddItem(sid uint64, item *Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop, ok := s.shops[sid]
+	if !ok {
+		return fmt.Errorf("shopeetest: no shop %d", sid)
+	}
+	if item.ItemID == 0 {
+		item.ItemID = s.newID()
+	}
+	if item.Status == "" {
+		item.Status = "NORMAL"
+	}
+	if item.UpdateTime == 0 {
+		item.UpdateTime = s.Now().Unix()
+	}
+	for _, m := range item.Models {
+		if m.ModelID == 0 {
+			m.ModelID = s.newID()
+		}
+	}
+	shop.items[item.ItemID] = item
+	return nil
+}
+
+// AddOrder adds order to shop sid. A zero create time is now.
+func (s *Server) AddOrder(sid uint64, order *Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop, ok := s.shops[sid]
+	if !ok {
+		return fmt.Errorf("shopeetest: no shop %d", sid)
+	}
+	if order.Status == "" {
+		order.Status = "READY_TO_SHIP"
+	}
+	if order.CreateTime == 0 {
+		order.CreateTime = s.Now().Unix()
+	}
+	if order.UpdateTime == 0 {
+		order.UpdateTime = order.CreateTime
+	}
+	shop.orders[order.OrderSN] = order
+	return nil
+}
+
+// Item returns a copy of item itemID of shop sid, to check what requests
+// did to it
+func (s *Server) Item(sid, itemID uint64) (Item, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop, ok := s.shops[sid]
+	if !ok {
+		return Item{}, false
+	}
+	item, ok := shop.items[itemID]
+	if !ok {
+		return Item{}, false
+	}
+	cp := *item
+	cp.Models = make([]*Model, len(item.Models))
+	for i, m := range item.Models {
+		mc := *m
+		cp.Models[i] = &mc
+	}
+	return cp, true
+}
+
+// Order returns a copy of order sn of shop sid
+func (s *Server) Order(sid uint64, sn string) (Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop, ok := s.shops[sid]
+	if !ok {
+		return Order{}, false
+	}
+	order, ok := shop.orders[sn]
+	if !ok {
+		return Order{}, false
+	}
+	return *order, true
+}
+
+// Discounts returns copies of the discounts of shop sid
+func (s *Server) Discounts(sid uint64) []Discount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shop, ok := s.shops[sid]
+	if !ok {
+		return nil
+	}
+	var res []Discount
+	for _, d := range shop.discounts {
+		res = append(res, *d)
+	}
+	return res
+}
+
+func (it *Item) model(id uint64) *Model {
+	for _, m := range it.Models {
+		if m.ModelID == id {
+			return m
+		}
+	}
+	return nil
+}
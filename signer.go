@@ -0,0 +1,148 @@
+package goshopee
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature is returned by Signer.VerifyURL for a wrong sign
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrStaleTimestamp is returned by Signer.VerifyURL for a timestamp
+	// further from now than Signer.MaxSkew
+	ErrStaleTimestamp = errors.New("stale timestamp")
+)
+
+// DefaultMaxSkew is how far from now the timestamp of a signed url may be,
+// as Shopee accepts it
+const DefaultMaxSkew = 5 * time.Minute
+
+// Signer computes the HMAC-SHA256 signatures of the Shopee api.
+// https://open.shopee.com/developer-guide/20
+//
+//	public apis:   partner_id, api path, timestamp
+//	shop apis:     partner_id, api path, timestamp, access_token, shop_id
+//	merchant apis: partner_id, api path, timestamp, access_token, merchant_id
+type Signer struct {
+	PartnerID  int
+	PartnerKey string
+
+	// Now is the clock of the timestamps, time.Now when nil
+	Now func() time.Time
+
+	// MaxSkew is how far from now the timestamp of a verified url may be,
+	// DefaultMaxSkew when 0
+	MaxSkew time.Duration
+}
+
+// NewSigner returns a Signer for the partner partnerID
+func NewSigner(partnerID int, partnerKey string) *Signer {
+	return &Signer{PartnerID: partnerID, PartnerKey: partnerKey}
+}
+
+func (s *Signer) now() time.Time {
+	if s.Now == nil {
+		return time.Now()
+	}
+	return s.Now()
+}
+
+// Timestamp returns the current timestamp of the signer clock
+func (s *Signer) Timestamp() int64 {
+	return s.now().Unix()
+}
+
+// PublicBaseString returns the string signed for a public api
+func (s *Signer) PublicBaseString(path string, ts int64) string {
+	return fmt.Sprintf("%d%s%d", s.PartnerID, path, ts)
+}
+
+// ShopBaseString returns the string signed for a shop api
+func (s *Signer) ShopBaseString(path string, ts int64, tok string, sid uint64) string {
+	return fmt.Sprintf("%d%s%d%s%d", s.PartnerID, path, ts, tok, sid)
+}
+
+// MerchantBaseString returns the string signed for a merchant api
+func (s *Signer) MerchantBaseString(path string, ts int64, tok string, mid uint64) string {
+	return fmt.Sprintf("%d%s%d%s%d", s.PartnerID, path, ts, tok, mid)
+}
+
+// Sign returns the signature of baseStr
+func (s *Signer) Sign(baseStr string) string {
+	return hmacSHA256(s.PartnerKey, baseStr)
+}
+
+// SignPublic signs path, the full api path like /api/v2/shop/auth_partner,
+// as a public api at the current timestamp
+func (s *Signer) SignPublic(path string) (string, int64) {
+	ts := s.Timestamp()
+	return s.Sign(s.PublicBaseString(path, ts)), ts
+}
+
+// SignShop signs path as an api of the shop sid with the access token tok
+func (s *Signer) SignShop(path, tok string, sid uint64) (string, int64) {
+	ts := s.Timestamp()
+	return s.Sign(s.ShopBaseString(path, ts, tok, sid)), ts
+}
+
+// SignMerchant signs path as an api of the merchant mid with the access
+// token tok
+func (s *Signer) SignMerchant(path, tok string, mid uint64) (string, int64) {
+	ts := s.Timestamp()
+	return s.Sign(s.MerchantBaseString(path, ts, tok, mid)), ts
+}
+
+// VerifyURL checks the partner_id, timestamp and sign query parameters of
+// u, e.g. an auth url or a request forwarded by a gateway. Urls with a
+// shop_id or merchant_id are verified as shop or merchant apis.
+func (s *Signer) VerifyURL(u *url.URL) error {
+	q := u.Query()
+	if q.Get("partner_id") != strconv.Itoa(s.PartnerID) {
+		return fmt.Errorf("%w: wrong partner_id %q", ErrInvalidSignature, q.Get("partner_id"))
+	}
+	ts, err := strconv.ParseInt(q.Get("timestamp"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp %q", ErrInvalidSignature, q.Get("timestamp"))
+	}
+
+	var base string
+	switch {
+	case q.Get("shop_id") != "":
+		sid, err := strconv.ParseUint(q.Get("shop_id"), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: invalid shop_id %q", ErrInvalidSignature, q.Get("shop_id"))
+		}
+		base = s.ShopBaseString(u.Path, ts, q.Get("access_token"), sid)
+	case q.Get("merchant_id") != "":
+		mid, err := strconv.ParseUint(q.Get("merchant_id"), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: invalid merchant_id %q", ErrInvalidSignature, q.Get("merchant_id"))
+		}
+		base = s.MerchantBaseString(u.Path, ts, q.Get("access_token"), mid)
+	default:
+		base = s.PublicBaseString(u.Path, ts)
+	}
+	if !hmac.Equal([]byte(s.Sign(base)), []byte(q.Get("sign"))) {
+		return ErrInvalidSignature
+	}
+
+	skew := s.MaxSkew
+	if skew == 0 {
+		skew = DefaultMaxSkew
+	}
+	if d := s.now().Sub(time.Unix(ts, 0)); d > skew || d < -skew {
+		return ErrStaleTimestamp
+	}
+	return nil
+}
+
+// VerifyPush reports whether auth, the Authorization header of a push, is
+// the signature of callbackURL and body.
+// https://open.shopee.com/developer-guide/11
+func (s *Signer) VerifyPush(callbackURL string, body []byte, auth string) bool {
+	return hmac.Equal([]byte(s.Sign(callbackURL+"|"+string(body))), []byte(auth))
+}
@@ -0,0 +1,103 @@
+package goshopee
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func fixedSigner() *Signer {
+	s := NewSigner(2001887, "hush")
+	s.Now = func() time.Time { return time.Unix(1655714431, 0) }
+	return s
+}
+
+func Test_SignerBaseStrings(t *testing.T) {
+	s := fixedSigner()
+	path := "/api/v2/shop/get_shop_info"
+
+	cases := []struct {
+		base     string
+		expected string
+	}{
+		{s.PublicBaseString(path, 1655714431), "2001887/api/v2/shop/get_shop_info1655714431"},
+		{s.ShopBaseString(path, 1655714431, "tok", 14701711), "2001887/api/v2/shop/get_shop_info1655714431tok14701711"},
+		{s.MerchantBaseString(path, 1655714431, "tok", 8), "2001887/api/v2/shop/get_shop_info1655714431tok8"},
+	}
+	for _, c := range cases {
+		if c.base != c.expected {
+			t.Errorf("base string %s, expected %s", c.base, c.expected)
+		}
+	}
+
+	sign, ts := s.SignShop(path, "tok", 14701711)
+	if ts != 1655714431 || sign != hmacSHA256("hush", cases[1].expected) {
+		t.Errorf("SignShop returned %s %d", sign, ts)
+	}
+}
+
+func Test_SignerVerifyURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := fixedSigner()
+	s.PartnerID = app.PartnerID
+	s.PartnerKey = app.PartnerKey
+	c := NewClient(app, WithSigner(s))
+	httpmock.ActivateNonDefault(c.Client)
+
+	var signed []*url.URL
+	httpmock.RegisterNoResponder(func(req *http.Request) (*http.Response, error) {
+		signed = append(signed, req.URL)
+		return httpmock.NewStringResponse(200, `{}`), nil
+	})
+	c.Shop.GetProfile(shopID, accessToken)
+	c.Merchant.GetShopListByMerchant(1, 1, 10, accessToken)
+	c.Push.GetAppPushConfig()
+
+	auth, _ := c.Auth.GetAuthURL()
+	u, _ := url.Parse(auth)
+	signed = append(signed, u)
+
+	if len(signed) != 4 {
+		t.Fatalf("%d requests signed, expected 4", len(signed))
+	}
+	for _, u := range signed {
+		if err := s.VerifyURL(u); err != nil {
+			t.Errorf("VerifyURL(%s) returned %s", u, err)
+		}
+	}
+
+	tampered := *signed[0]
+	q := tampered.Query()
+	q.Set("shop_id", "1")
+	tampered.RawQuery = q.Encode()
+	if err := s.VerifyURL(&tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyURL of another shop returned %v", err)
+	}
+
+	s.Now = func() time.Time { return time.Unix(1655714431, 0).Add(6 * time.Minute) }
+	if err := s.VerifyURL(signed[0]); !errors.Is(err, ErrStaleTimestamp) {
+		t.Errorf("VerifyURL 6 minutes later returned %v", err)
+	}
+}
+
+func Test_SignerVerifyPush(t *testing.T) {
+	s := fixedSigner()
+	body := []byte(`{"code":3}`)
+	auth := s.Sign("https://example.com/push|" + string(body))
+
+	if !s.VerifyPush("https://example.com/push", body, auth) {
+		t.Errorf("VerifyPush rejected a valid signature")
+	}
+	if s.VerifyPush("https://example.com/other", body, auth) {
+		t.Errorf("VerifyPush accepted the signature of another url")
+	}
+	if !VerifyPushSignature("hush", "https://example.com/push", body, auth) {
+		t.Errorf("VerifyPushSignature rejected a valid signature")
+	}
+}
@@ -0,0 +1,118 @@
+package goshopee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// RequestLog describes one attempt of an api request. Bodies are already
+// redacted.
+type RequestLog struct {
+	Method       string
+	Path         string
+	ShopID       uint64
+	MerchantID   uint64
+	Status       int
+	Duration     time.Duration
+	RequestID    string
+	Attempt      int
+	Err          error
+	RequestBody  []byte
+	ResponseBody []byte
+}
+
+// RequestLogger is implemented by loggers wanting each request as one
+// structured entry instead of the Debugf lines of LeveledLoggerInterface.
+type RequestLogger interface {
+	LogRequest(ctx context.Context, e RequestLog)
+}
+
+// RequestBodyLogger is a RequestLogger telling whether it logs bodies. When
+// LogsBodies is false the bodies are not read and redacted, RequestBody and
+// ResponseBody are left empty.
+type RequestBodyLogger interface {
+	RequestLogger
+	LogsBodies(ctx context.Context) bool
+}
+
+// SlogLogger adapts a *slog.Logger to LeveledLoggerInterface and
+// RequestLogger. Requests are logged at debug level with the fields method,
+// path, shop_id, merchant_id, status, duration, request_id and attempt,
+// failed ones at warn level with an error field.
+type SlogLogger struct {
+	Logger *slog.Logger
+
+	// LogBodies adds the redacted request and response bodies to debug
+	// entries
+	LogBodies bool
+}
+
+// NewSlogLogger returns a SlogLogger writing to l, or to slog.Default if l is
+// nil.
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &SlogLogger{Logger: l}
+}
+
+func (l *SlogLogger) Debugf(format string, v ...interface{}) {
+	l.Logger.Debug(fmt.Sprintf(format, v...))
+}
+
+func (l *SlogLogger) Errorf(format string, v ...interface{}) {
+	l.Logger.Error(fmt.Sprintf(format, v...))
+}
+
+func (l *SlogLogger) Infof(format string, v ...interface{}) {
+	l.Logger.Info(fmt.Sprintf(format, v...))
+}
+
+func (l *SlogLogger) Warnf(format string, v ...interface{}) {
+	l.Logger.Warn(fmt.Sprintf(format, v...))
+}
+
+// LogsBodies reports whether LogBodies is set and debug entries are enabled
+func (l *SlogLogger) LogsBodies(ctx context.Context) bool {
+	return l.LogBodies && l.Logger.Enabled(ctx, slog.LevelDebug)
+}
+
+func (l *SlogLogger) LogRequest(ctx context.Context, e RequestLog) {
+	level := slog.LevelDebug
+	if e.Err != nil {
+		level = slog.LevelWarn
+	}
+	if !l.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", e.Method),
+		slog.String("path", e.Path),
+	}
+	if e.ShopID != 0 {
+		attrs = append(attrs, slog.Uint64("shop_id", e.ShopID))
+	}
+	if e.MerchantID != 0 {
+		attrs = append(attrs, slog.Uint64("merchant_id", e.MerchantID))
+	}
+	attrs = append(attrs,
+		slog.Int("status", e.Status),
+		slog.Duration("duration", e.Duration),
+		slog.String("request_id", e.RequestID),
+		slog.Int("attempt", e.Attempt),
+	)
+	if e.Err != nil {
+		attrs = append(attrs, slog.String("error", e.Err.Error()))
+	}
+	if l.LogsBodies(ctx) {
+		attrs = append(attrs,
+			slog.String("request_body", string(e.RequestBody)),
+			slog.String("response_body", string(e.ResponseBody)),
+		)
+	}
+
+	l.Logger.LogAttrs(ctx, level, "shopee request", attrs...)
+}
@@ -0,0 +1,133 @@
+package goshopee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_SlogLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	logger.LogBodies = true
+
+	c := NewClient(app, WithLogger(logger), WithRetry(1))
+	httpmock.ActivateNonDefault(c.Client)
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/order/get_order_detail", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return httpmock.NewStringResponse(200, `{"error":"error_busy","message":"busy","request_id":"r1"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"request_id":"r2","response":{"order_list":[{"order_sn":"SN1","recipient_address":{"phone":"6512345678"}}]}}`), nil
+		})
+
+	if _, err := c.Order.GetOrderDetail(shopID, []string{"SN1"}, nil, accessToken); err != nil {
+		t.Fatalf("Order.GetOrderDetail error: %s", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "6512345678") || strings.Contains(out, accessToken) {
+		t.Errorf("log contains buyer data or token: %s", out)
+	}
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("log line %q: %s", line, err)
+		}
+		if e["msg"] == "shopee request" {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) != 2 {
+		t.Fatalf("logged %d requests, expected 2: %s", len(entries), out)
+	}
+
+	first, second := entries[0], entries[1]
+	if first["level"] != "WARN" || first["request_id"] != "r1" || first["attempt"] != float64(1) {
+		t.Errorf("first attempt logged as %v", first)
+	}
+	if second["level"] != "DEBUG" || second["request_id"] != "r2" || second["attempt"] != float64(2) ||
+		second["status"] != float64(200) || second["shop_id"] != float64(shopID) || second["path"] != "/api/v2/order/get_order_detail" {
+		t.Errorf("second attempt logged as %v", second)
+	}
+}
+
+func Test_SlogLoggerTransportError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	c := NewClient(app, WithLogger(logger))
+	httpmock.ActivateNonDefault(c.Client)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		httpmock.NewErrorResponder(errors.New("connection reset")))
+
+	_, err := c.Shop.GetProfile(shopID, accessToken)
+	if err == nil {
+		t.Fatal("Shop.GetProfile returned no error")
+	}
+	hidden := "sign=" + url.QueryEscape(redacted)
+	if strings.Contains(err.Error(), accessToken) || !strings.Contains(err.Error(), hidden) {
+		t.Errorf("error contains the token or sign: %s", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "connection reset") || strings.Contains(out, accessToken) {
+		t.Errorf("log contains the token: %s", out)
+	}
+}
+
+func Test_SlogLoggerSkipsBodies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	logger.LogBodies = true
+	if logger.LogsBodies(context.Background()) {
+		t.Errorf("LogsBodies is true with debug entries disabled")
+	}
+
+	rec := &bodyRecorder{SlogLogger: logger}
+	c := NewClient(app, WithLogger(rec))
+	httpmock.ActivateNonDefault(c.Client)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("get_profile_resp.json")))
+
+	res, err := c.Shop.GetProfile(shopID, accessToken)
+	if err != nil {
+		t.Fatalf("Shop.GetProfile error: %s", err)
+	}
+	if res.Response.ShopName == "" {
+		t.Errorf("Shop.GetProfile returned %+v", res.Response)
+	}
+	if len(rec.entries) != 1 || rec.entries[0].ResponseBody != nil {
+		t.Errorf("bodies read for a logger not logging them: %+v", rec.entries)
+	}
+}
+
+type bodyRecorder struct {
+	*SlogLogger
+	entries []RequestLog
+}
+
+func (r *bodyRecorder) LogRequest(ctx context.Context, e RequestLog) {
+	r.entries = append(r.entries, e)
+	r.SlogLogger.LogRequest(ctx, e)
+}
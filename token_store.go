@@ -0,0 +1,312 @@
+package goshopee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrTokenNotFound is returned by a TokenStore when it holds no token for a key.
+var ErrTokenNotFound = errors.New("token not found")
+
+// Token is the access/refresh token pair issued for one shop or merchant.
+// Access tokens are valid for 4 hours, refresh tokens for 30 days and are
+// rotated on every refresh.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpireAt     time.Time `json:"expire_at"`
+}
+
+// expiresWithin reports whether the access token is expired at now+d.
+func (t *Token) expiresWithin(now time.Time, d time.Duration) bool {
+	return !t.ExpireAt.After(now.Add(d))
+}
+
+// Token converts the response into a Token expiring ExpireIn seconds from now.
+func (r *AccessTokenResponse) Token() *Token {
+	return &Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		ExpireAt:     time.Now().Add(time.Duration(r.ExpireIn) * time.Second),
+	}
+}
+
+// Token converts the response into a Token expiring ExpireIn seconds from now.
+func (r *RefreshAccessTokenResponse) Token() *Token {
+	return &Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		ExpireAt:     time.Now().Add(time.Duration(r.ExpireIn) * time.Second),
+	}
+}
+
+// TokenKey identifies the owner of a token. Only one of ShopID and
+// MerchantID is set.
+type TokenKey struct {
+	ShopID     uint64
+	MerchantID uint64
+}
+
+// ShopTokenKey returns the key of the token authorized for shop sid.
+func ShopTokenKey(sid uint64) TokenKey {
+	return TokenKey{ShopID: sid}
+}
+
+// MerchantTokenKey returns the key of the token authorized for merchant mid.
+func MerchantTokenKey(mid uint64) TokenKey {
+	return TokenKey{MerchantID: mid}
+}
+
+func (k TokenKey) String() string {
+	if k.MerchantID != 0 {
+		return fmt.Sprintf("merchant:%d", k.MerchantID)
+	}
+	return fmt.Sprintf("shop:%d", k.ShopID)
+}
+
+// TokenStore persists tokens so the client can look them up and save the
+// rotated refresh token after a refresh. Implementations must be safe for
+// concurrent use.
+type TokenStore interface {
+	// GetToken returns the token stored for key, or ErrTokenNotFound.
+	GetToken(ctx context.Context, key TokenKey) (*Token, error)
+	// SetToken stores tok for key, replacing any previous token.
+	SetToken(ctx context.Context, key TokenKey, tok *Token) error
+}
+
+// MemoryTokenStore keeps tokens in memory. Tokens are lost when the process
+// exits.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[TokenKey]Token
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[TokenKey]Token{}}
+}
+
+func (s *MemoryTokenStore) GetToken(ctx context.Context, key TokenKey) (*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tok, ok := s.tokens[key]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return &tok, nil
+}
+
+func (s *MemoryTokenStore) SetToken(ctx context.Context, key TokenKey, tok *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = *tok
+	return nil
+}
+
+// FileTokenStore keeps tokens in a JSON file, keyed by TokenKey.String().
+// Writes go to a temporary file that is renamed over the old one, so a crash
+// never leaves a truncated file behind.
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore returns a store backed by the file at path. The file is
+// created on the first SetToken.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (s *FileTokenStore) GetToken(ctx context.Context, key TokenKey) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	tok, ok := tokens[key.String()]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return tok, nil
+}
+
+func (s *FileTokenStore) SetToken(ctx context.Context, key TokenKey, tok *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+	tokens[key.String()] = tok
+
+	byts, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, byts)
+}
+
+// writeFileAtomic writes to a temporary file renamed over path
+func writeFileAtomic(path string, byts []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(byts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (s *FileTokenStore) load() (map[string]*Token, error) {
+	tokens := map[string]*Token{}
+
+	byts, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(byts) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(byts, &tokens); err != nil {
+		return nil, fmt.Errorf("error to decode token file %s: %s", s.path, err)
+	}
+	return tokens, nil
+}
+
+// refresh the access token this long before it expires
+const defaultTokenRefreshMargin = 5 * time.Minute
+
+// tokenManager looks tokens up in a TokenStore and refreshes them when they
+// are about to expire. Concurrent refreshes of the same key share one call to
+// Shopee, since every refresh invalidates the previous refresh token.
+type tokenManager struct {
+	store  TokenStore
+	client *Client
+	margin time.Duration
+	now    func() time.Time
+
+	mu       sync.Mutex
+	inflight map[TokenKey]*tokenRefreshCall
+}
+
+type tokenRefreshCall struct {
+	done chan struct{}
+	tok  *Token
+	err  error
+}
+
+func newTokenManager(c *Client, store TokenStore) *tokenManager {
+	return &tokenManager{
+		store:    store,
+		client:   c,
+		margin:   defaultTokenRefreshMargin,
+		now:      time.Now,
+		inflight: map[TokenKey]*tokenRefreshCall{},
+	}
+}
+
+// token returns a usable access token for key, refreshing it first if it
+// expires within the refresh margin.
+func (m *tokenManager) token(ctx context.Context, key TokenKey) (*Token, error) {
+	tok, err := m.store.GetToken(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("error to get token for %s: %w", key, err)
+	}
+	if !tok.expiresWithin(m.now(), m.margin) {
+		return tok, nil
+	}
+	return m.refresh(ctx, key, tok.AccessToken)
+}
+
+// refresh replaces the access token stale with a new one. If another caller
+// already replaced it, the newer token is returned without calling Shopee.
+func (m *tokenManager) refresh(ctx context.Context, key TokenKey, stale string) (*Token, error) {
+	m.mu.Lock()
+	call, ok := m.inflight[key]
+	if !ok {
+		call = &tokenRefreshCall{done: make(chan struct{})}
+		m.inflight[key] = call
+		go m.doRefresh(key, stale, call)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-call.done:
+		return call.tok, call.err
+	}
+}
+
+// doRefresh runs detached from the callers' contexts so that one cancelled
+// caller does not fail the refresh for everyone waiting on it.
+func (m *tokenManager) doRefresh(key TokenKey, stale string, call *tokenRefreshCall) {
+	defer func() {
+		m.mu.Lock()
+		delete(m.inflight, key)
+		m.mu.Unlock()
+		close(call.done)
+	}()
+
+	ctx := context.Background()
+
+	cur, err := m.store.GetToken(ctx, key)
+	if err != nil {
+		call.err = fmt.Errorf("error to get token for %s: %w", key, err)
+		return
+	}
+	if cur.AccessToken != stale && !cur.expiresWithin(m.now(), m.margin) {
+		call.tok = cur
+		return
+	}
+
+	res, err := m.client.Auth.RefreshAccessTokenWithContext(ctx, key.ShopID, key.MerchantID, cur.RefreshToken)
+	if err != nil {
+		call.err = fmt.Errorf("error to refresh token for %s: %w", key, err)
+		return
+	}
+
+	tok := res.Token()
+	if err := m.store.SetToken(ctx, key, tok); err != nil {
+		call.err = fmt.Errorf("error to save token for %s: %w", key, err)
+		return
+	}
+	m.client.log.Debugf("refreshed access token for %s, expire at %s", key, tok.ExpireAt)
+	call.tok = tok
+}
+
+// shopee answers requests signed with an expired or revoked token with one of these
+var invalidTokenErrors = map[string]bool{
+	"invalid_access_token":  true,
+	"invalid_acceess_token": true,
+}
+
+func isInvalidTokenError(err error) bool {
+	var respErr ResponseError
+	if errors.As(err, &respErr) {
+		return invalidTokenErrors[respErr.Code]
+	}
+	return false
+}
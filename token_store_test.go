@@ -0,0 +1,123 @@
+package goshopee
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func Test_FileTokenStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goshopee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewFileTokenStore(filepath.Join(dir, "tokens.json"))
+	ctx := context.Background()
+
+	if _, err := store.GetToken(ctx, ShopTokenKey(shopID)); err != ErrTokenNotFound {
+		t.Errorf("FileTokenStore.GetToken returned %v, expected %v", err, ErrTokenNotFound)
+	}
+
+	expected := &Token{AccessToken: "a", RefreshToken: "r", ExpireAt: time.Unix(1700000000, 0)}
+	if err := store.SetToken(ctx, ShopTokenKey(shopID), expected); err != nil {
+		t.Fatalf("FileTokenStore.SetToken error: %s", err)
+	}
+	if err := store.SetToken(ctx, MerchantTokenKey(shopID), &Token{AccessToken: "m"}); err != nil {
+		t.Fatalf("FileTokenStore.SetToken error: %s", err)
+	}
+
+	res, err := NewFileTokenStore(filepath.Join(dir, "tokens.json")).GetToken(ctx, ShopTokenKey(shopID))
+	if err != nil {
+		t.Fatalf("FileTokenStore.GetToken error: %s", err)
+	}
+	if res.RefreshToken != expected.RefreshToken || !res.ExpireAt.Equal(expected.ExpireAt) {
+		t.Errorf("FileTokenStore.GetToken returned %+v, expected %+v", res, expected)
+	}
+}
+
+func setupTokenStore(tok Token) (*Client, TokenStore) {
+	store := NewMemoryTokenStore()
+	store.SetToken(context.Background(), ShopTokenKey(shopID), &tok)
+
+	c := NewClient(app, WithRetry(maxRetries), WithTokenStore(store))
+	httpmock.ActivateNonDefault(c.Client)
+	return c, store
+}
+
+func registerRefreshResponder(refreshes *int32) {
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v2/auth/access_token/get", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(refreshes, 1)
+			body := fmt.Sprintf(`{"access_token":"new-%d","refresh_token":"refresh-%d","expire_in":14400}`, n, n)
+			return httpmock.NewStringResponse(200, body), nil
+		})
+}
+
+func Test_TokenStoreRefreshBeforeExpiry(t *testing.T) {
+	c, store := setupTokenStore(Token{AccessToken: "old", RefreshToken: "refresh-0", ExpireAt: time.Now().Add(time.Minute)})
+	defer teardown()
+
+	var refreshes int32
+	registerRefreshResponder(&refreshes)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			if tok := req.URL.Query().Get("access_token"); tok != "new-1" {
+				return httpmock.NewStringResponse(403, `{"error":"invalid_access_token","message":"Invalid access_token."}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_profile_resp.json")), nil
+		})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Shop.GetProfile(shopID, ""); err != nil {
+				t.Errorf("Shop.GetProfile error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes != 1 {
+		t.Errorf("token refreshed %d times, expected 1", refreshes)
+	}
+
+	tok, _ := store.GetToken(context.Background(), ShopTokenKey(shopID))
+	if tok.RefreshToken != "refresh-1" {
+		t.Errorf("stored RefreshToken %s, expected refresh-1", tok.RefreshToken)
+	}
+}
+
+func Test_TokenStoreRefreshAfterInvalidToken(t *testing.T) {
+	c, _ := setupTokenStore(Token{AccessToken: "revoked", RefreshToken: "refresh-0", ExpireAt: time.Now().Add(time.Hour)})
+	defer teardown()
+
+	var refreshes int32
+	registerRefreshResponder(&refreshes)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/shop/get_profile", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			if tok := req.URL.Query().Get("access_token"); tok == "revoked" {
+				return httpmock.NewStringResponse(403, `{"error":"invalid_access_token","message":"Invalid access_token."}`), nil
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("get_profile_resp.json")), nil
+		})
+
+	if _, err := c.Shop.GetProfile(shopID, ""); err != nil {
+		t.Errorf("Shop.GetProfile error: %s", err)
+	}
+	if refreshes != 1 {
+		t.Errorf("token refreshed %d times, expected 1", refreshes)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"time"
 )
 
 type UtilService interface {
@@ -17,15 +16,20 @@ type UtilServiceOp struct {
 	client *Client
 }
 
+// Sign signs the api path plainText as a public api, see Signer.SignPublic
 func (s *UtilServiceOp)Sign(plainText string) (string,int64,error) {
-	ts:=time.Now().Unix()
-	baseStr := fmt.Sprintf("%d%s%d",s.client.app.PartnerID,plainText,ts)
-	h := hmac.New(sha256.New, []byte(s.client.app.PartnerKey))
-	h.Write([]byte(baseStr))
-	result := hex.EncodeToString(h.Sum(nil))
+	result, ts := s.client.signer.SignPublic(plainText)
 	return result,ts,nil
 }
 
+// hmacSHA256 returns the hex encoded HMAC-SHA256 of baseStr, which is how
+// shopee signs both API requests and push notifications
+func hmacSHA256(key, baseStr string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(baseStr))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func StructToMap(in interface{}) (map[string]interface{},error) {
 	byts,err:=json.Marshal(in)
 	if err!=nil {
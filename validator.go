@@ -0,0 +1,442 @@
+package goshopee
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// https://open.shopee.com/documents/v2/v2.product.get_attributes?module=89&type=1
+const (
+	AttributeInputDropDown               = "DROP_DOWN"
+	AttributeInputMultipleSelect         = "MULTIPLE_SELECT"
+	AttributeInputTextField              = "TEXT_FILED" // sic
+	AttributeInputComboBox               = "COMBO_BOX"
+	AttributeInputMultipleSelectComboBox = "MULTIPLE_SELECT_COMBO_BOX"
+
+	AttributeValidationInt       = "INT_TYPE"
+	AttributeValidationString    = "STRING_TYPE"
+	AttributeValidationEnum      = "ENUM_TYPE"
+	AttributeValidationFloat     = "FLOAT_TYPE"
+	AttributeValidationDate      = "DATE_TYPE"
+	AttributeValidationTimestamp = "TIMESTAMP_TYPE"
+
+	// logistics channels priced by size need a size_id
+	LogisticsFeeTypeSizeSelection = "SIZE_SELECTION"
+
+	brandStatusNormal = 1
+	brandListPageSize = 100
+)
+
+// ViolationCode tells what rule a Violation breaks
+type ViolationCode string
+
+const (
+	ViolationUnknownCategory       ViolationCode = "unknown_category"
+	ViolationNotLeafCategory       ViolationCode = "not_leaf_category"
+	ViolationMissingAttribute      ViolationCode = "missing_attribute"
+	ViolationUnknownAttribute      ViolationCode = "unknown_attribute"
+	ViolationInvalidAttributeValue ViolationCode = "invalid_attribute_value"
+	ViolationMissingBrand          ViolationCode = "missing_brand"
+	ViolationUnsupportedBrand      ViolationCode = "unsupported_brand"
+	ViolationDaysToShip            ViolationCode = "days_to_ship"
+	ViolationSizeChart             ViolationCode = "size_chart"
+	ViolationLogistics             ViolationCode = "logistics"
+	ViolationWeight                ViolationCode = "weight"
+	ViolationDimension             ViolationCode = "dimension"
+)
+
+// Violation is a field of an AddItemRequest that Shopee would reject. Field
+// is the json path of the field, e.g. attribute_list[1].attribute_value_list.
+type Violation struct {
+	Field   string
+	Code    ViolationCode
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Field, v.Message)
+}
+
+// Violations is the result of a validation. It is an error when not empty.
+type Violations []Violation
+
+func (vs Violations) Error() string {
+	msgs := make([]string, len(vs))
+	for i, v := range vs {
+		msgs[i] = v.String()
+	}
+	return "invalid item: " + strings.Join(msgs, "; ")
+}
+
+// Has reports whether vs has a violation with code
+func (vs Violations) Has(code ViolationCode) bool {
+	for _, v := range vs {
+		if v.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (vs *Violations) add(field string, code ViolationCode, format string, args ...interface{}) {
+	*vs = append(*vs, Violation{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// ItemRules are the rules an item of a category must follow. Fetch them once
+// with ItemValidator.Rules and Check many items of the category.
+type ItemRules struct {
+	Category         Category
+	Attributes       []Attribute
+	Brands           []Brand
+	BrandMandatory   bool
+	DTSLimit         GetDTSLimitResponseData
+	SupportSizeChart bool
+	Channels         []LogisticsChannel
+}
+
+// ItemValidator checks AddItemRequest against the rules of its category
+// before it is sent:
+//
+//	v := goshopee.NewItemValidator(client)
+//	vs, err := v.Validate(ctx, sid, item, tok)
+//	for _, violation := range vs {
+//		// violation.Field, violation.Code, violation.Message
+//	}
+type ItemValidator struct {
+	Product   ProductService
+	Logistics LogisticsService
+
+	// Language of the category and attribute names, optional
+	Language string
+
+	// Categories caches the category tree of Region when set, instead of
+	// fetching it on each validation
+	Categories *CategoryCache
+	Region     string
+}
+
+// NewItemValidator returns a validator fetching rules with the services of c
+func NewItemValidator(c *Client) *ItemValidator {
+	return &ItemValidator{Product: c.Product, Logistics: c.Logistics}
+}
+
+// Validate fetches the rules of the category of item and checks it. Unknown
+// or non leaf categories are reported without fetching the other rules.
+func (v *ItemValidator) Validate(ctx context.Context, sid uint64, item AddItemRequest, tok string) (Violations, error) {
+	rules, vs, err := v.rules(ctx, sid, item.CategoryID, tok)
+	if err != nil || len(vs) > 0 {
+		return vs, err
+	}
+	return rules.Check(item), nil
+}
+
+// Rules fetches the rules of the category cid
+func (v *ItemValidator) Rules(ctx context.Context, sid, cid uint64, tok string) (*ItemRules, error) {
+	rules, vs, err := v.rules(ctx, sid, cid, tok)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) > 0 {
+		return nil, vs
+	}
+	return rules, nil
+}
+
+func (v *ItemValidator) rules(ctx context.Context, sid, cid uint64, tok string) (*ItemRules, Violations, error) {
+	var vs Violations
+	tree, err := v.categories(ctx, sid, tok)
+	if err != nil {
+		return nil, nil, err
+	}
+	category, ok := tree.Get(cid)
+	if !ok {
+		vs.add("category_id", ViolationUnknownCategory, "unknown category %d", cid)
+		return nil, vs, nil
+	}
+	rules := &ItemRules{Category: category.Category}
+	if !category.IsLeaf() {
+		vs.add("category_id", ViolationNotLeafCategory, "category %d has sub categories, use one of them", cid)
+		return nil, vs, nil
+	}
+
+	attrs, err := v.Product.GetAttributesWithContext(ctx, sid, cid, v.Language, tok)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules.Attributes = attrs.Response.AttributeList
+
+	brands := &brandListRecorder{ProductService: v.Product}
+	rules.Brands, err = NewBrandListPager(brands, sid, cid, brandStatusNormal, brandListPageSize, tok).All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules.BrandMandatory = brands.mandatory
+
+	dts, err := v.Product.GetDTSLimitWithContext(ctx, sid, cid, tok)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules.DTSLimit = dts.Response
+
+	sizeChart, err := v.Product.SupportSizeChartWithContext(ctx, sid, cid, tok)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules.SupportSizeChart = sizeChart.Response.SupportSizeChart
+
+	channels, err := v.Logistics.GetChannelListWithContext(ctx, sid, tok)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules.Channels = channels.Response.LogisticsChannelList
+
+	return rules, nil, nil
+}
+
+func (v *ItemValidator) categories(ctx context.Context, sid uint64, tok string) (*CategoryTree, error) {
+	if v.Categories != nil {
+		tree, err := v.Categories.Tree(ctx, sid, v.Region, v.Language, tok)
+		if tree != nil {
+			// a tree comes with an error only when it could not be cached
+			return tree, nil
+		}
+		return nil, err
+	}
+	res, err := v.Product.GetCategoryWithContext(ctx, sid, v.Language, tok)
+	if err != nil {
+		return nil, err
+	}
+	return NewCategoryTree(res.Response.CategoryList), nil
+}
+
+// brandListRecorder keeps is_mandatory of the brand list pages fetched
+// through it
+type brandListRecorder struct {
+	ProductService
+	mandatory bool
+}
+
+func (r *brandListRecorder) GetBrandListWithContext(ctx context.Context, sid, cid uint64, status, offset, pageSize int, tok string) (*GetBrandListResponse, error) {
+	res, err := r.ProductService.GetBrandListWithContext(ctx, sid, cid, status, offset, pageSize, tok)
+	if err == nil {
+		r.mandatory = res.Response.IsMandatory
+	}
+	return res, err
+}
+
+// Check returns the violations of the rules by item, nil if there are none
+func (r *ItemRules) Check(item AddItemRequest) Violations {
+	var vs Violations
+	r.checkAttributes(item, &vs)
+	r.checkBrand(item, &vs)
+	r.checkDaysToShip(item, &vs)
+	r.checkLogistics(item, &vs)
+
+	if item.SizeChart != "" && !r.SupportSizeChart {
+		vs.add("size_chart", ViolationSizeChart, "category %d does not support size charts", r.Category.CategoryID)
+	}
+	return vs
+}
+
+func (r *ItemRules) checkAttributes(item AddItemRequest, vs *Violations) {
+	given := make(map[uint64]ItemAttribute)
+	for i, a := range item.AttributeList {
+		field := fmt.Sprintf("attribute_list[%d]", i)
+		attr, ok := r.attribute(a.AttributeID)
+		if !ok {
+			vs.add(field+".attribute_id", ViolationUnknownAttribute, "unknown attribute %d", a.AttributeID)
+			continue
+		}
+		given[a.AttributeID] = a
+		checkAttributeValues(field+".attribute_value_list", attr, a.AttributeValueList, vs)
+	}
+
+	for _, attr := range r.Attributes {
+		if a, ok := given[attr.AttributeID]; attr.IsMandatory && (!ok || len(a.AttributeValueList) == 0) {
+			vs.add("attribute_list", ViolationMissingAttribute, "mandatory attribute %d %q is missing", attr.AttributeID, attr.DisplayAttributeName)
+		}
+	}
+}
+
+func checkAttributeValues(field string, attr Attribute, values []ItemAttributeValue, vs *Violations) {
+	if len(values) > 1 && !attr.multiple() {
+		vs.add(field, ViolationInvalidAttributeValue, "attribute %d takes a single value", attr.AttributeID)
+	}
+	for i, val := range values {
+		vfield := fmt.Sprintf("%s[%d]", field, i)
+		if val.ValueId != 0 {
+			if !attr.hasValue(val.ValueId) {
+				vs.add(vfield+".value_id", ViolationInvalidAttributeValue, "value %d is not a value of attribute %d", val.ValueId, attr.AttributeID)
+			}
+			continue
+		}
+
+		if !attr.custom() {
+			vs.add(vfield+".value_id", ViolationInvalidAttributeValue, "attribute %d takes one of its values, not a custom value", attr.AttributeID)
+			continue
+		}
+		if strings.TrimSpace(val.OriginalValueName) == "" {
+			vs.add(vfield+".original_value_name", ViolationInvalidAttributeValue, "custom value of attribute %d is empty", attr.AttributeID)
+			continue
+		}
+		if err := checkAttributeFormat(attr, val.OriginalValueName); err != nil {
+			vs.add(vfield+".original_value_name", ViolationInvalidAttributeValue, "attribute %d: %s", attr.AttributeID, err)
+		}
+		if units := attr.units(); len(units) > 0 && !containsFold(units, val.ValueUnit) {
+			vs.add(vfield+".value_unit", ViolationInvalidAttributeValue, "unit %q of attribute %d is not one of %s", val.ValueUnit, attr.AttributeID, strings.Join(units, ", "))
+		}
+	}
+}
+
+// checkAttributeFormat checks a custom value against the validation type of
+// attr
+func checkAttributeFormat(attr Attribute, s string) error {
+	switch attr.InputValidationType {
+	case AttributeValidationInt, AttributeValidationTimestamp:
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+	case AttributeValidationFloat:
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+	case AttributeValidationDate:
+		layout := "02/01/2006"
+		if attr.DateFormatType == "MONTH_YEAR" {
+			layout = "01/2006"
+		}
+		if _, err := time.Parse(layout, s); err != nil {
+			return fmt.Errorf("%q is not a date like %s", s, layout)
+		}
+	}
+	return nil
+}
+
+func (r *ItemRules) attribute(id uint64) (Attribute, bool) {
+	for _, a := range r.Attributes {
+		if a.AttributeID == id {
+			return a, true
+		}
+	}
+	return Attribute{}, false
+}
+
+func (a Attribute) hasValue(id uint64) bool {
+	for _, v := range a.AttributeValueList {
+		if v.ValueID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// units returns the units of the attribute, without the empty ones Shopee
+// sends for attributes without unit
+func (a Attribute) units() []string {
+	var units []string
+	for _, u := range a.AttributeUnit {
+		if u != "" {
+			units = append(units, u)
+		}
+	}
+	return units
+}
+
+func (r *ItemRules) checkBrand(item AddItemRequest, vs *Violations) {
+	if item.Brand.BrandID == 0 {
+		// 0 is "No brand"
+		if r.BrandMandatory {
+			vs.add("brand.brand_id", ViolationMissingBrand, "category %d needs a brand", r.Category.CategoryID)
+		}
+		return
+	}
+	for _, b := range r.Brands {
+		if b.BrandID == item.Brand.BrandID {
+			return
+		}
+	}
+	vs.add("brand.brand_id", ViolationUnsupportedBrand, "brand %d is not available in category %d", item.Brand.BrandID, r.Category.CategoryID)
+}
+
+func (r *ItemRules) checkDaysToShip(item AddItemRequest, vs *Violations) {
+	dts := item.PreOrder.DaysToShip
+	if !item.PreOrder.IsPreOrder {
+		if dts != nil && r.DTSLimit.NonPreOrderDaysToShip != 0 && *dts != r.DTSLimit.NonPreOrderDaysToShip {
+			vs.add("pre_order.days_to_ship", ViolationDaysToShip, "days to ship is %d for items not in pre order", r.DTSLimit.NonPreOrderDaysToShip)
+		}
+		return
+	}
+
+	limit := r.DTSLimit.DaysToShipLimit
+	switch {
+	case dts == nil:
+		vs.add("pre_order.days_to_ship", ViolationDaysToShip, "pre order items need days to ship")
+	case *dts < limit.MinLimit || (limit.MaxLimit > 0 && *dts > limit.MaxLimit):
+		vs.add("pre_order.days_to_ship", ViolationDaysToShip, "days to ship %d is out of %d to %d", *dts, limit.MinLimit, limit.MaxLimit)
+	}
+}
+
+func (r *ItemRules) checkLogistics(item AddItemRequest, vs *Violations) {
+	if item.Weight <= 0 {
+		vs.add("weight", ViolationWeight, "weight is required")
+	}
+
+	enabled := 0
+	for i, li := range item.LogisticInfo {
+		if !li.Enabled {
+			continue
+		}
+		enabled++
+		field := fmt.Sprintf("logistic_info[%d]", i)
+
+		ch, ok := r.channel(li.LogisticID)
+		if !ok || !ch.Enabled {
+			vs.add(field+".logistic_id", ViolationLogistics, "logistics channel %d is not enabled for the shop", li.LogisticID)
+			continue
+		}
+		if ch.FeeType == LogisticsFeeTypeSizeSelection && li.SizeID == 0 {
+			vs.add(field+".size_id", ViolationLogistics, "logistics channel %d needs a size_id", li.LogisticID)
+		}
+
+		w := ch.WeightLimit
+		if item.Weight > 0 && ((w.ItemMaxWeight > 0 && item.Weight > w.ItemMaxWeight) || item.Weight < w.ItemMinWeight) {
+			vs.add("weight", ViolationWeight, "weight %gkg is out of %g to %gkg of logistics channel %d", item.Weight, w.ItemMinWeight, w.ItemMaxWeight, li.LogisticID)
+		}
+
+		if d, max := item.Dimension, ch.ItemMaxDimension; d != nil {
+			if exceeds(d.PackageHeight, max.Height) || exceeds(d.PackageWidth, max.Width) || exceeds(d.PackageLength, max.Length) {
+				vs.add("dimension", ViolationDimension, "dimension %dx%dx%d is over %gx%gx%g%s of logistics channel %d",
+					d.PackageLength, d.PackageWidth, d.PackageHeight, max.Length, max.Width, max.Height, max.Unit, li.LogisticID)
+			}
+		}
+	}
+	if enabled == 0 {
+		vs.add("logistic_info", ViolationLogistics, "enable at least one logistics channel")
+	}
+}
+
+func (r *ItemRules) channel(id uint64) (LogisticsChannel, bool) {
+	for _, ch := range r.Channels {
+		if ch.LogisticsChannelID == id {
+			return ch, true
+		}
+	}
+	return LogisticsChannel{}, false
+}
+
+// exceeds reports whether v is over max, a 0 max being no limit
+func exceeds(v int, max float64) bool {
+	return max > 0 && float64(v) > max
+}
+
+func containsFold(list []string, s string) bool {
+	for _, it := range list {
+		if strings.EqualFold(it, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package goshopee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func testItemRules() *ItemRules {
+	return &ItemRules{
+		Category: Category{CategoryID: 123},
+		Attributes: []Attribute{
+			{AttributeID: 1, DisplayAttributeName: "Material", IsMandatory: true, InputType: AttributeInputDropDown,
+				AttributeValueList: []AttributeValue{{ValueID: 11}, {ValueID: 12}}},
+			{AttributeID: 2, DisplayAttributeName: "Weight", InputType: AttributeInputTextField,
+				InputValidationType: AttributeValidationFloat, AttributeUnit: []string{"g", "kg"}},
+			{AttributeID: 3, DisplayAttributeName: "Style", InputType: AttributeInputMultipleSelectComboBox,
+				AttributeValueList: []AttributeValue{{ValueID: 31}}},
+		},
+		Brands:           []Brand{{BrandID: 7}},
+		DTSLimit:         GetDTSLimitResponseData{DaysToShipLimit: DaysToShipLimit{MinLimit: 7, MaxLimit: 30}, NonPreOrderDaysToShip: 3},
+		SupportSizeChart: false,
+		Channels: []LogisticsChannel{
+			{LogisticsChannelID: 100, Enabled: true, WeightLimit: WeightLimit{ItemMaxWeight: 5, ItemMinWeight: 0.01},
+				ItemMaxDimension: ItemMaxDimension{Height: 30, Width: 30, Length: 30, Unit: "cm"}},
+			{LogisticsChannelID: 200, Enabled: true, FeeType: LogisticsFeeTypeSizeSelection},
+			{LogisticsChannelID: 300},
+		},
+	}
+}
+
+func validItem() AddItemRequest {
+	return AddItemRequest{ItemBase: ItemBase{
+		CategoryID: 123,
+		Weight:     1,
+		Dimension:  &Dimension{PackageHeight: 10, PackageLength: 10, PackageWidth: 10},
+		AttributeList: []ItemAttribute{
+			{AttributeID: 1, AttributeValueList: []ItemAttributeValue{{ValueId: 11}}},
+			{AttributeID: 2, AttributeValueList: []ItemAttributeValue{{OriginalValueName: "250", ValueUnit: "g"}}},
+			{AttributeID: 3, AttributeValueList: []ItemAttributeValue{{ValueId: 31}, {OriginalValueName: "casual"}}},
+		},
+		LogisticInfo: []LogisticInfo{{LogisticID: 100, Enabled: true}},
+		Brand:        ItemBrand{BrandID: 7},
+	}}
+}
+
+func Test_ItemRulesCheck(t *testing.T) {
+	rules := testItemRules()
+	if vs := rules.Check(validItem()); len(vs) != 0 {
+		t.Fatalf("valid item returned %s", vs)
+	}
+
+	dts := func(n int) *int { return &n }
+	cases := []struct {
+		name   string
+		change func(*AddItemRequest)
+		field  string
+		code   ViolationCode
+	}{
+		{"missing mandatory attribute", func(it *AddItemRequest) { it.AttributeList = it.AttributeList[1:] },
+			"attribute_list", ViolationMissingAttribute},
+		{"unknown attribute", func(it *AddItemRequest) { it.AttributeList[1].AttributeID = 9 },
+			"attribute_list[1].attribute_id", ViolationUnknownAttribute},
+		{"unknown value", func(it *AddItemRequest) { it.AttributeList[0].AttributeValueList[0].ValueId = 13 },
+			"attribute_list[0].attribute_value_list[0].value_id", ViolationInvalidAttributeValue},
+		{"custom value of drop down", func(it *AddItemRequest) {
+			it.AttributeList[0].AttributeValueList[0] = ItemAttributeValue{OriginalValueName: "silk"}
+		}, "attribute_list[0].attribute_value_list[0].value_id", ViolationInvalidAttributeValue},
+		{"several values of drop down", func(it *AddItemRequest) {
+			it.AttributeList[0].AttributeValueList = append(it.AttributeList[0].AttributeValueList, ItemAttributeValue{ValueId: 12})
+		}, "attribute_list[0].attribute_value_list", ViolationInvalidAttributeValue},
+		{"not a number", func(it *AddItemRequest) { it.AttributeList[1].AttributeValueList[0].OriginalValueName = "light" },
+			"attribute_list[1].attribute_value_list[0].original_value_name", ViolationInvalidAttributeValue},
+		{"wrong unit", func(it *AddItemRequest) { it.AttributeList[1].AttributeValueList[0].ValueUnit = "lb" },
+			"attribute_list[1].attribute_value_list[0].value_unit", ViolationInvalidAttributeValue},
+		{"unsupported brand", func(it *AddItemRequest) { it.Brand.BrandID = 8 },
+			"brand.brand_id", ViolationUnsupportedBrand},
+		{"pre order without days to ship", func(it *AddItemRequest) { it.PreOrder.IsPreOrder = true },
+			"pre_order.days_to_ship", ViolationDaysToShip},
+		{"pre order too long", func(it *AddItemRequest) { it.PreOrder = ItemPreOrder{IsPreOrder: true, DaysToShip: dts(31)} },
+			"pre_order.days_to_ship", ViolationDaysToShip},
+		{"days to ship not in pre order", func(it *AddItemRequest) { it.PreOrder.DaysToShip = dts(5) },
+			"pre_order.days_to_ship", ViolationDaysToShip},
+		{"size chart", func(it *AddItemRequest) { it.SizeChart = "img" },
+			"size_chart", ViolationSizeChart},
+		{"no logistics", func(it *AddItemRequest) { it.LogisticInfo[0].Enabled = false },
+			"logistic_info", ViolationLogistics},
+		{"disabled channel", func(it *AddItemRequest) { it.LogisticInfo[0].LogisticID = 300 },
+			"logistic_info[0].logistic_id", ViolationLogistics},
+		{"missing size", func(it *AddItemRequest) { it.LogisticInfo[0].LogisticID = 200 },
+			"logistic_info[0].size_id", ViolationLogistics},
+		{"missing weight", func(it *AddItemRequest) { it.Weight = 0 },
+			"weight", ViolationWeight},
+		{"too heavy", func(it *AddItemRequest) { it.Weight = 6 },
+			"weight", ViolationWeight},
+		{"too large", func(it *AddItemRequest) { it.Dimension.PackageLength = 31 },
+			"dimension", ViolationDimension},
+	}
+	for _, c := range cases {
+		item := validItem()
+		c.change(&item)
+		vs := rules.Check(item)
+		if len(vs) != 1 || vs[0].Field != c.field || vs[0].Code != c.code {
+			t.Errorf("%s: Check returned %#v, expected %s at %s", c.name, vs, c.code, c.field)
+		}
+	}
+
+	rules.BrandMandatory = true
+	item := validItem()
+	item.Brand.BrandID = 0
+	if vs := rules.Check(item); !vs.Has(ViolationMissingBrand) {
+		t.Errorf("item without mandatory brand returned %s", vs)
+	}
+}
+
+func Test_ItemValidatorValidate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_category", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("category_list.json")))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_attributes", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("attributes.json")))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_brand_list", app.APIURL),
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("offset") == "0" {
+				return httpmock.NewBytesResponse(200, loadFixture("brand_list.json")), nil
+			}
+			return httpmock.NewStringResponse(200, `{"response":{"brand_list":[{"brand_id":2500139862}],"has_next_page":false,"is_mandatory":true}}`), nil
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_dts_limit", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("dts_limit.json")))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/support_size_chart", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("support_size_chart.json")))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/logistics/get_channel_list", app.APIURL),
+		httpmock.NewBytesResponder(200, loadFixture("channel_list.json")))
+
+	v := NewItemValidator(client)
+	rules, err := v.Rules(context.Background(), shopID, 123, accessToken)
+	if err != nil {
+		t.Fatalf("ItemValidator.Rules error: %s", err)
+	}
+	if len(rules.Brands) != 2 || !rules.BrandMandatory {
+		t.Errorf("Rules returned %d brands, mandatory %v, expected 2, true", len(rules.Brands), rules.BrandMandatory)
+	}
+
+	item := AddItemRequest{ItemBase: ItemBase{
+		CategoryID:   123,
+		Weight:       0.5,
+		LogisticInfo: []LogisticInfo{{LogisticID: 50015, Enabled: true}},
+		Brand:        ItemBrand{BrandID: 2500139862},
+		SizeChart:    "img",
+	}}
+	vs, err := v.Validate(context.Background(), shopID, item, accessToken)
+	if err != nil {
+		t.Fatalf("ItemValidator.Validate error: %s", err)
+	}
+	if len(vs) != 1 || vs[0].Code != ViolationSizeChart {
+		t.Errorf("Validate returned %s, expected a size chart violation", vs)
+	}
+
+	item.CategoryID = 456
+	vs, err = v.Validate(context.Background(), shopID, item, accessToken)
+	if err != nil || len(vs) != 1 || vs[0].Code != ViolationUnknownCategory {
+		t.Errorf("Validate of an unknown category returned %v, %v", vs, err)
+	}
+	if _, err := v.Rules(context.Background(), shopID, 456, accessToken); err == nil {
+		t.Errorf("Rules of an unknown category returned no error")
+	}
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v2/product/get_brand_list", app.APIURL),
+		httpmock.NewStringResponder(200, `{"response":{"brand_list":[],"has_next_page":true,"next_offset":0}}`))
+	if _, err := v.Rules(context.Background(), shopID, 123, accessToken); !errors.Is(err, ErrPageNotAdvancing) {
+		t.Errorf("Rules with a stuck brand list returned %v, expected ErrPageNotAdvancing", err)
+	}
+}
@@ -0,0 +1,309 @@
+package goshopee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// Push codes of shopee push notifications
+// https://open.shopee.com/developer-guide/11
+const (
+	PushCodeShopAuthorization          = 1
+	PushCodeShopAuthorizationCanceled  = 2
+	PushCodeOrderStatus                = 3
+	PushCodeOrderTrackingNo            = 4
+	PushCodeShopeeUpdates              = 5
+	PushCodeBannedItem                 = 6
+	PushCodeItemPromotion              = 7
+	PushCodeReservedStockChange        = 8
+	PushCodePromotionUpdate            = 9
+	PushCodeWebchat                    = 10
+	PushCodeVideoUpload                = 11
+	PushCodeOpenAPIAuthorizationExpiry = 12
+	PushCodeBrandRegisterResult        = 13
+	PushCodeShippingDocumentStatus     = 15
+)
+
+// shopee never sends large push bodies, cap what we read
+const maxPushBodySize = 1 << 20
+
+// PushMessage is the envelope of every push notification. Data holds the
+// code specific payload, decode it with the typed Push structs below.
+type PushMessage struct {
+	Code          int             `json:"code"`
+	ShopID        uint64          `json:"shop_id"`
+	MerchantID    uint64          `json:"merchant_id"`
+	MainAccountID uint64          `json:"main_account_id"`
+	Timestamp     int64           `json:"timestamp"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// ShopAuthorizationPush is the data of PushCodeShopAuthorization and
+// PushCodeShopAuthorizationCanceled
+type ShopAuthorizationPush struct {
+	ShopID        uint64 `json:"shop_id"`
+	MerchantID    uint64 `json:"merchant_id"`
+	MainAccountID uint64 `json:"main_account_id"`
+	Success       int    `json:"success"`
+	Extra         string `json:"extra"`
+}
+
+// OrderStatusPush is the data of PushCodeOrderStatus
+type OrderStatusPush struct {
+	OrderSN           string      `json:"ordersn"`
+	Status            OrderStatus `json:"status"`
+	CompletedScenario string      `json:"completed_scenario"`
+	UpdateTime        int64       `json:"update_time"`
+}
+
+// OrderTrackingNoPush is the data of PushCodeOrderTrackingNo
+type OrderTrackingNoPush struct {
+	OrderSN       string `json:"ordersn"`
+	ForderID      string `json:"forder_id"`
+	PackageNumber string `json:"package_number"`
+	TrackingNo    string `json:"tracking_no"`
+}
+
+// ShopeeUpdatesPush is the data of PushCodeShopeeUpdates
+type ShopeeUpdatesPush struct {
+	Actions []ShopeeUpdatesPushAction `json:"actions"`
+}
+
+type ShopeeUpdatesPushAction struct {
+	Content    string `json:"content"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	UpdateTime int64  `json:"update_time"`
+}
+
+// BannedItemPush is the data of PushCodeBannedItem
+type BannedItemPush struct {
+	ItemID     uint64 `json:"item_id"`
+	ItemName   string `json:"item_name"`
+	ItemStatus string `json:"item_status"`
+	BanReason  string `json:"ban_reason"`
+	Suggestion string `json:"suggestion"`
+	UpdateTime int64  `json:"update_time"`
+}
+
+// ItemPromotionPush is the data of PushCodeItemPromotion
+type ItemPromotionPush struct {
+	ItemID        uint64 `json:"item_id"`
+	ModelID       uint64 `json:"variation_id"`
+	PromotionType string `json:"promotion_type"`
+	PromotionID   uint64 `json:"promotion_id"`
+	Action        string `json:"action"`
+	StartTime     int64  `json:"start_time"`
+	EndTime       int64  `json:"end_time"`
+	UpdateTime    int64  `json:"update_time"`
+}
+
+// ReservedStockChangePush is the data of PushCodeReservedStockChange
+type ReservedStockChangePush struct {
+	ItemID        uint64                         `json:"item_id"`
+	ModelID       uint64                         `json:"variation_id"`
+	Action        string                         `json:"action"`
+	PromotionType string                         `json:"promotion_type"`
+	PromotionID   uint64                         `json:"promotion_id"`
+	ChangedValues []ReservedStockChangePushValue `json:"changed_values"`
+	UpdateTime    int64                          `json:"update_time"`
+}
+
+type ReservedStockChangePushValue struct {
+	Name string `json:"name"`
+	Old  int    `json:"old"`
+	New  int    `json:"new"`
+}
+
+// PromotionUpdatePush is the data of PushCodePromotionUpdate
+type PromotionUpdatePush struct {
+	PromotionType string `json:"promotion_type"`
+	PromotionID   uint64 `json:"promotion_id"`
+	ItemID        uint64 `json:"item_id"`
+	ModelID       uint64 `json:"variation_id"`
+	Action        string `json:"action"`
+	StartTime     int64  `json:"start_time"`
+	EndTime       int64  `json:"end_time"`
+	UpdateTime    int64  `json:"update_time"`
+}
+
+// PushFunc handles one push message. A returned error makes the handler
+// answer with a 500 status, so shopee delivers the message again later.
+type PushFunc func(ctx context.Context, msg *PushMessage) error
+
+// PushHandler is an http.Handler receiving shopee push notifications. It
+// verifies the Authorization signature of every request with the partner key,
+// decodes the message and dispatches it to the callbacks registered for its
+// code.
+type PushHandler struct {
+	partnerKey  string
+	callbackURL string
+
+	mu       sync.RWMutex
+	handlers map[int][]PushFunc
+
+	// called for codes without callbacks, may be nil
+	NotFound PushFunc
+}
+
+// NewPushHandler returns a handler verifying pushes with app.PartnerKey.
+// callbackURL must be the exact URL set as push callback in the partner
+// console, since shopee signs it. If empty, it is rebuilt from each request,
+// which only works when no proxy rewrites scheme, host or path.
+func NewPushHandler(app App, callbackURL string) *PushHandler {
+	return &PushHandler{
+		partnerKey:  app.PartnerKey,
+		callbackURL: callbackURL,
+		handlers:    map[int][]PushFunc{},
+	}
+}
+
+// Handle registers fn for messages with code. Callbacks of one code run in
+// the order they were registered.
+func (h *PushHandler) Handle(code int, fn PushFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[code] = append(h.handlers[code], fn)
+}
+
+// OnShopAuthorization registers fn for PushCodeShopAuthorization.
+func (h *PushHandler) OnShopAuthorization(fn func(context.Context, *PushMessage, *ShopAuthorizationPush) error) {
+	on(h, PushCodeShopAuthorization, fn)
+}
+
+// OnShopAuthorizationCanceled registers fn for PushCodeShopAuthorizationCanceled.
+func (h *PushHandler) OnShopAuthorizationCanceled(fn func(context.Context, *PushMessage, *ShopAuthorizationPush) error) {
+	on(h, PushCodeShopAuthorizationCanceled, fn)
+}
+
+// OnOrderStatus registers fn for PushCodeOrderStatus.
+func (h *PushHandler) OnOrderStatus(fn func(context.Context, *PushMessage, *OrderStatusPush) error) {
+	on(h, PushCodeOrderStatus, fn)
+}
+
+// OnOrderTrackingNo registers fn for PushCodeOrderTrackingNo.
+func (h *PushHandler) OnOrderTrackingNo(fn func(context.Context, *PushMessage, *OrderTrackingNoPush) error) {
+	on(h, PushCodeOrderTrackingNo, fn)
+}
+
+// OnShopeeUpdates registers fn for PushCodeShopeeUpdates.
+func (h *PushHandler) OnShopeeUpdates(fn func(context.Context, *PushMessage, *ShopeeUpdatesPush) error) {
+	on(h, PushCodeShopeeUpdates, fn)
+}
+
+// OnBannedItem registers fn for PushCodeBannedItem.
+func (h *PushHandler) OnBannedItem(fn func(context.Context, *PushMessage, *BannedItemPush) error) {
+	on(h, PushCodeBannedItem, fn)
+}
+
+// OnItemPromotion registers fn for PushCodeItemPromotion.
+func (h *PushHandler) OnItemPromotion(fn func(context.Context, *PushMessage, *ItemPromotionPush) error) {
+	on(h, PushCodeItemPromotion, fn)
+}
+
+// OnReservedStockChange registers fn for PushCodeReservedStockChange.
+func (h *PushHandler) OnReservedStockChange(fn func(context.Context, *PushMessage, *ReservedStockChangePush) error) {
+	on(h, PushCodeReservedStockChange, fn)
+}
+
+// OnPromotionUpdate registers fn for PushCodePromotionUpdate.
+func (h *PushHandler) OnPromotionUpdate(fn func(context.Context, *PushMessage, *PromotionUpdatePush) error) {
+	on(h, PushCodePromotionUpdate, fn)
+}
+
+// on registers fn for code, with the data of the message decoded into a T
+func on[T any](h *PushHandler, code int, fn func(context.Context, *PushMessage, *T) error) {
+	h.Handle(code, func(ctx context.Context, msg *PushMessage) error {
+		data := new(T)
+		if err := msg.decode(data); err != nil {
+			return err
+		}
+		return fn(ctx, msg, data)
+	})
+}
+
+// decode unmarshals the code specific data of msg into v
+func (msg *PushMessage) decode(v interface{}) error {
+	if len(msg.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(msg.Data, v); err != nil {
+		return fmt.Errorf("error to decode push data of code %d: %s", msg.Code, err)
+	}
+	return nil
+}
+
+func (h *PushHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxPushBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	callbackURL := h.callbackURL
+	if callbackURL == "" {
+		callbackURL = requestURL(r)
+	}
+	if !VerifyPushSignature(h.partnerKey, callbackURL, body, r.Header.Get("Authorization")) {
+		http.Error(w, "invalid push signature", http.StatusUnauthorized)
+		return
+	}
+
+	msg := new(PushMessage)
+	if err := json.Unmarshal(body, msg); err != nil {
+		http.Error(w, fmt.Sprintf("error to decode push message: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), msg); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *PushHandler) dispatch(ctx context.Context, msg *PushMessage) error {
+	h.mu.RLock()
+	fns := h.handlers[msg.Code]
+	h.mu.RUnlock()
+
+	if len(fns) == 0 && h.NotFound != nil {
+		fns = []PushFunc{h.NotFound}
+	}
+	for _, fn := range fns {
+		if err := fn(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyPushSignature reports whether auth, the Authorization header of a
+// push, is the signature of callbackURL and body with partnerKey.
+// https://open.shopee.com/developer-guide/11
+func VerifyPushSignature(partnerKey, callbackURL string, body []byte, auth string) bool {
+	return (&Signer{PartnerKey: partnerKey}).VerifyPush(callbackURL, body, auth)
+}
+
+// requestURL rebuilds the absolute URL a request was sent to
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, r.URL.RequestURI())
+}
@@ -0,0 +1,89 @@
+package goshopee
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const pushCallbackURL = "https://example.com/shopee/push"
+
+func newPushRequest(body, key string) *http.Request {
+	req := httptest.NewRequest("POST", pushCallbackURL, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", hmacSHA256(key, pushCallbackURL+"|"+body))
+	return req
+}
+
+func Test_PushHandlerOrderStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	h := NewPushHandler(app, pushCallbackURL)
+
+	var got *OrderStatusPush
+	h.OnOrderStatus(func(ctx context.Context, msg *PushMessage, data *OrderStatusPush) error {
+		got = data
+		return nil
+	})
+
+	body := `{"data":{"ordersn":"2011300B5BNNP2","status":"READY_TO_SHIP","update_time":1606712534},"shop_id":` +
+		`1234567,"code":3,"timestamp":1606712534}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newPushRequest(body, app.PartnerKey))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("PushHandler returned status %d, expected %d", w.Code, http.StatusOK)
+	}
+	if got == nil || got.OrderSN != "2011300B5BNNP2" || !got.Status.CanShip() {
+		t.Errorf("OnOrderStatus got %+v, expected order 2011300B5BNNP2 ready to ship", got)
+	}
+}
+
+func Test_PushHandlerInvalidSignature(t *testing.T) {
+	setup()
+	defer teardown()
+
+	h := NewPushHandler(app, pushCallbackURL)
+	h.Handle(PushCodeOrderStatus, func(ctx context.Context, msg *PushMessage) error {
+		t.Errorf("callback called for a push with invalid signature")
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newPushRequest(`{"code":3,"shop_id":1234567}`, "wrongkey"))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("PushHandler returned status %d, expected %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func Test_PushHandlerCallbackError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	h := NewPushHandler(app, "")
+	h.OnOrderTrackingNo(func(ctx context.Context, msg *PushMessage, data *OrderTrackingNoPush) error {
+		return errors.New("database down")
+	})
+
+	var notFound int
+	h.NotFound = func(ctx context.Context, msg *PushMessage) error {
+		notFound = msg.Code
+		return nil
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newPushRequest(`{"code":4,"data":{"ordersn":"SN123","tracking_no":"TN1"}}`, app.PartnerKey))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("PushHandler returned status %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newPushRequest(`{"code":6,"data":{"item_id":1}}`, app.PartnerKey))
+	if w.Code != http.StatusOK || notFound != PushCodeBannedItem {
+		t.Errorf("PushHandler returned status %d and NotFound got code %d, expected %d and %d", w.Code, notFound, http.StatusOK, PushCodeBannedItem)
+	}
+}